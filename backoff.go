@@ -0,0 +1,100 @@
+package wechat
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Backoff allows callers to implement their own Backoff strategy.
+type Backoff interface {
+	// Next implements a BackoffFunc. retry starts at 1.
+	Next(retry int) (time.Duration, bool)
+}
+
+// -- ZeroBackoff --
+
+// ZeroBackoff is a fixed backoff policy whose backoff time is always zero,
+// meaning that the operation is retried immediately without waiting,
+// indefinitely.
+type ZeroBackoff struct{}
+
+// Next implements BackoffFunc for ZeroBackoff.
+func (b ZeroBackoff) Next(retry int) (time.Duration, bool) {
+	return 0, true
+}
+
+// -- StopBackoff --
+
+// StopBackoff is a fixed backoff policy that always returns false for
+// Next(), meaning that the operation should never be retried.
+type StopBackoff struct{}
+
+// Next implements BackoffFunc for StopBackoff.
+func (b StopBackoff) Next(retry int) (time.Duration, bool) {
+	return 0, false
+}
+
+// -- ConstantBackoff --
+
+// ConstantBackoff is a backoff policy that always returns the same delay,
+// at most maxRetries times.
+type ConstantBackoff struct {
+	interval   time.Duration
+	maxRetries int
+}
+
+// NewConstantBackoff returns a new ConstantBackoff.
+func NewConstantBackoff(interval time.Duration, maxRetries int) *ConstantBackoff {
+	return &ConstantBackoff{
+		interval:   interval,
+		maxRetries: maxRetries,
+	}
+}
+
+// Next implements BackoffFunc for ConstantBackoff.
+func (b *ConstantBackoff) Next(retry int) (time.Duration, bool) {
+	if retry > b.maxRetries {
+		return 0, false
+	}
+	return b.interval, true
+}
+
+// -- Exponential --
+
+// ExponentialBackoff implements the simple exponential backoff with full
+// jitter: the n-th retry waits a random time between initial*2^(n-1)/2 and
+// initial*2^(n-1), capped at max. It gives up after maxRetries retries.
+type ExponentialBackoff struct {
+	initial    time.Duration
+	max        time.Duration
+	maxRetries int
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewExponentialBackoff returns a ExponentialBackoff backoff policy.
+// Use initial to set the first wait, max to cap every single wait and
+// maxRetries to limit the number of retries.
+func NewExponentialBackoff(initial, max time.Duration, maxRetries int) *ExponentialBackoff {
+	return &ExponentialBackoff{
+		initial:    initial,
+		max:        max,
+		maxRetries: maxRetries,
+		rnd:        rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Next implements BackoffFunc for ExponentialBackoff.
+func (b *ExponentialBackoff) Next(retry int) (time.Duration, bool) {
+	if retry > b.maxRetries {
+		return 0, false
+	}
+	d := math.Min(float64(b.initial)*math.Pow(2, float64(retry-1)), float64(b.max))
+	b.mu.Lock()
+	r := b.rnd.Float64()
+	b.mu.Unlock()
+	return time.Duration(d/2 + r*d/2), true
+}
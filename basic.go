@@ -7,4 +7,45 @@ const (
 	MiniProgramBaseHost = "api.weixin.qq.com"
 	// WorkBaseHost work base uri
 	WorkBaseHost = "qyapi.weixin.qq.com"
+	// OpenPlatformBaseHost open platform base uri
+	OpenPlatformBaseHost = "open.weixin.qq.com"
+	// PayBaseHost wechat pay base uri
+	PayBaseHost = "api.mch.weixin.qq.com"
 )
+
+// Backup and regional hosts of api.weixin.qq.com
+const (
+	BackupBaseHost   = "api2.weixin.qq.com"
+	ShanghaiBaseHost = "sh.api.weixin.qq.com"
+	ShenzhenBaseHost = "sz.api.weixin.qq.com"
+	HongKongBaseHost = "hk.api.weixin.qq.com"
+)
+
+// DefaultAPIHosts are the hosts used by SetFailover, in order of preference.
+var DefaultAPIHosts = []string{
+	OfficeAccountBaseHost,
+	BackupBaseHost,
+	ShanghaiBaseHost,
+	ShenzhenBaseHost,
+	HongKongBaseHost,
+}
+
+// Logical hosts. Builders and IBasicMessage.BaseURI use these names instead
+// of real hosts, the client resolves them to a base url when performing a
+// request, see SetHostURL.
+const (
+	OfficeAccountHost = "officeaccount"
+	MiniProgramHost   = "miniprogram"
+	WorkHost          = "work"
+	OpenPlatformHost  = "openplatform"
+	PayHost           = "pay"
+)
+
+// defaultHosts maps logical hosts to the real wechat hosts.
+var defaultHosts = map[string]string{
+	OfficeAccountHost: OfficeAccountBaseHost,
+	MiniProgramHost:   MiniProgramBaseHost,
+	WorkHost:          WorkBaseHost,
+	OpenPlatformHost:  OpenPlatformBaseHost,
+	PayHost:           PayBaseHost,
+}
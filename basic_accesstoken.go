@@ -3,7 +3,10 @@ package wechat
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 const (
@@ -17,12 +20,33 @@ type IAccessToken interface {
 	ToString() string
 }
 
+// IForceAccessToken is implemented by sources that tell a normal fetch from
+// a forced refresh, like StableAccessToken. BasicAccessToken calls
+// ForceCredentials for RefreshToken and when wechat rejects a token, and
+// Credentials otherwise.
+type IForceAccessToken interface {
+	IAccessToken
+	ForceCredentials(ctx context.Context) (*AccessToken, error)
+}
+
 // AccessToken AccessToken
 type AccessToken struct {
 	AccessToken string `json:"access_token"`
 	ExpiresIn   int64  `json:"expires_in"`
 }
 
+// tokenMeta is cached next to a token, so the background refresher knows
+// when the token was issued, even by another process.
+type tokenMeta struct {
+	ExpiresIn int64 `json:"expires_in"`
+	CreatedAt int64 `json:"created_at"`
+}
+
+// due returns the time the token should be replaced.
+func (tm *tokenMeta) due(fraction float64) time.Time {
+	return time.Unix(tm.CreatedAt, 0).Add(time.Duration(float64(tm.ExpiresIn)*fraction) * time.Second)
+}
+
 // BasicAccessToken BasicAccessToken
 type BasicAccessToken struct {
 	client *Client
@@ -42,39 +66,265 @@ func (bat *BasicAccessToken) cacheKey() string {
 	return MD5Sum(fmt.Sprintf("%s%s", cachekeyPrefix, bat.iat.ToString()))
 }
 
+func (bat *BasicAccessToken) metaKey() string {
+	return bat.cacheKey() + ".meta"
+}
+
 // SetToken SetToken
 func (bat *BasicAccessToken) SetToken(ctx context.Context, at *AccessToken) error {
-	lifetime := at.ExpiresIn - int64(safeDuration/time.Second)
-	bat.client.cache.Set(ctx, bat.cacheKey(), at.AccessToken, time.Duration(lifetime)*time.Second)
-	return nil
+	lifetime := time.Duration(at.ExpiresIn-int64(safeDuration/time.Second)) * time.Second
+	if lifetime <= 0 {
+		// Tokens about to expire, e.g. a stable token near the end of its
+		// lifetime, are kept half of the time left.
+		lifetime = time.Duration(at.ExpiresIn) * time.Second / 2
+	}
+	if err := bat.client.cache.Set(ctx, bat.cacheKey(), at.AccessToken, lifetime); err != nil {
+		return err
+	}
+	meta := &tokenMeta{
+		ExpiresIn: at.ExpiresIn,
+		CreatedAt: time.Now().Unix(),
+	}
+	return bat.client.cache.Set(ctx, bat.metaKey(), meta, lifetime)
 }
 
 // GetToken GetToken
 func (bat *BasicAccessToken) GetToken(ctx context.Context, refresh bool) (accessToken string) {
-	if !refresh {
-		value, err := bat.client.cache.Get(ctx, bat.cacheKey())
-		bat.client.tracef("GetToken cache get err: %v", err)
-		if err == nil {
-			return value.(string)
+	accessToken, err := bat.token(ctx, refresh)
+	if err != nil {
+		bat.client.errorf("GetToken err: %v", err)
+	}
+	return accessToken
+}
+
+// RefreshToken RefreshToken
+func (bat *BasicAccessToken) RefreshToken(ctx context.Context) string {
+	return bat.GetToken(ctx, true)
+}
+
+// Invalidate removes the cached token and its metadata, the next GetToken
+// fetches a new one.
+func (bat *BasicAccessToken) Invalidate(ctx context.Context) error {
+	if err := bat.client.cache.Delete(ctx, bat.cacheKey()); err != nil {
+		return err
+	}
+	return bat.client.cache.Delete(ctx, bat.metaKey())
+}
+
+func (bat *BasicAccessToken) lockKey() string {
+	return bat.cacheKey() + ".lock"
+}
+
+// meta returns the cached metadata of the token.
+func (bat *BasicAccessToken) meta(ctx context.Context) (*tokenMeta, error) {
+	value, err := bat.client.cache.Get(ctx, bat.metaKey())
+	if err != nil {
+		return nil, err
+	}
+	meta := new(tokenMeta)
+	if err := UnmarshalCacheValue(value, meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// cached returns the cached token.
+func (bat *BasicAccessToken) cached(ctx context.Context) (string, error) {
+	value, err := bat.client.cache.Get(ctx, bat.cacheKey())
+	if err != nil {
+		return "", err
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	}
+	return "", fmt.Errorf("unexpected cached token type %T", value)
+}
+
+// token returns the cached token, or fetches and caches a new one if there
+// is none or refresh is true.
+func (bat *BasicAccessToken) token(ctx context.Context, refresh bool) (string, error) {
+	cached, err := bat.cached(ctx)
+	bat.client.tracef("GetToken cache get err: %v", err)
+	if err == nil && !refresh {
+		return cached, nil
+	}
+	at, err := bat.fetch(ctx, cached)
+	if err != nil {
+		return "", err
+	}
+	return at.AccessToken, nil
+}
+
+// fetch replaces the stale token, which is empty if none is cached, with a
+// new one. Concurrent fetches of the same token are deduplicated, so only
+// one Credentials call is in flight per cache key and the others wait for
+// its result.
+func (bat *BasicAccessToken) fetch(ctx context.Context, stale string) (*AccessToken, error) {
+	for {
+		v, shared, err := bat.client.tokenFlight.Do(ctx, bat.cacheKey(), func() (interface{}, error) {
+			return bat.refresh(ctx, stale, stale != "", func(ctx context.Context) (*AccessToken, bool) {
+				cached, err := bat.cached(ctx)
+				if err != nil || cached == stale {
+					return nil, false
+				}
+				return &AccessToken{AccessToken: cached}, true
+			})
+		})
+		// The context of the caller that ran Credentials is done, but ours
+		// isn't: try again instead of failing with someone else's error.
+		if shared && err != nil && ctx.Err() == nil && IsContextErr(errors.Cause(err)) {
+			continue
+		}
+		if err != nil {
+			return nil, err
 		}
-		refresh = true
+		return v.(*AccessToken), nil
 	}
-	if refresh {
-		at, err := bat.iat.Credentials(ctx)
+}
+
+// refreshIfDue replaces the cached token once fraction of its lifetime
+// has passed, see SetRefreshFraction. It returns when the token is due
+// next time.
+func (bat *BasicAccessToken) refreshIfDue(ctx context.Context, fraction float64) (time.Time, error) {
+	fresh := func(ctx context.Context) (*AccessToken, bool) {
+		meta, err := bat.meta(ctx)
+		if err != nil || !time.Now().Before(meta.due(fraction)) {
+			return nil, false
+		}
+		cached, err := bat.cached(ctx)
 		if err != nil {
-			bat.client.errorf("GetToken Credentials err: %v", err)
-			return
+			return nil, false
 		}
-		if err := bat.SetToken(ctx, at); err != nil {
-			bat.client.errorf("GetToken SetToken err: %v", err)
-			return
+		return &AccessToken{AccessToken: cached}, true
+	}
+	if _, ok := fresh(ctx); !ok {
+		stale, _ := bat.cached(ctx)
+		_, _, err := bat.client.tokenFlight.Do(ctx, bat.cacheKey(), func() (interface{}, error) {
+			return bat.refresh(ctx, stale, false, fresh)
+		})
+		if err != nil {
+			return time.Time{}, err
 		}
-		accessToken = at.AccessToken
 	}
-	return
+	meta, err := bat.meta(ctx)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "BasicAccessToken.meta")
+	}
+	return meta.due(fraction), nil
 }
 
-// RefreshToken RefreshToken
-func (bat *BasicAccessToken) RefreshToken(ctx context.Context) string {
-	return bat.GetToken(ctx, true)
+// refresh fetches a new token through Credentials, or ForceCredentials if
+// force is true and supported, and caches it, unless fresh reports that the
+// stale token has already been replaced in the meantime. With a Locker,
+// this is done while holding the lock of the token, so other processes
+// sharing the cache see the new token after waiting for it.
+func (bat *BasicAccessToken) refresh(ctx context.Context, stale string, force bool, fresh func(context.Context) (*AccessToken, bool)) (*AccessToken, error) {
+	bat.client.mu.RLock()
+	locker, lease := bat.client.locker, bat.client.lockLease
+	bat.client.mu.RUnlock()
+
+	if locker != nil {
+		token, err := locker.Lock(ctx, bat.lockKey(), lease)
+		if err != nil {
+			return nil, errors.Wrap(err, "BasicAccessToken.Lock")
+		}
+		defer func() {
+			// Release the lock even if ctx is done already.
+			uctx, cancel := context.WithTimeout(context.Background(), lease)
+			defer cancel()
+			if err := locker.Unlock(uctx, bat.lockKey(), token); err != nil {
+				bat.client.errorf("BasicAccessToken.Unlock err: %v", err)
+			}
+		}()
+	}
+	// Check again, the stale token may have been replaced while waiting.
+	if at, ok := fresh(ctx); ok {
+		bat.client.tracef("BasicAccessToken token already replaced")
+		return at, nil
+	}
+	if stale != "" {
+		if err := bat.Invalidate(ctx); err != nil {
+			bat.client.errorf("BasicAccessToken.Invalidate err: %v", err)
+		}
+	}
+	credentials := bat.iat.Credentials
+	if fiat, ok := bat.iat.(IForceAccessToken); ok && force {
+		credentials = fiat.ForceCredentials
+	}
+	at, err := credentials(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "BasicAccessToken.Credentials")
+	}
+	if err := bat.SetToken(ctx, at); err != nil {
+		return nil, errors.Wrap(err, "BasicAccessToken.SetToken")
+	}
+	return at, nil
+}
+
+// PerformRequest does a HTTP request to wechat with the access_token param
+// set. If wechat rejects the token as invalid or expired, the cached token
+// is dropped and the request is sent once more with a fresh token.
+func (bat *BasicAccessToken) PerformRequest(ctx context.Context, opt PerformRequestOptions) (*Response, error) {
+	return bat.perform(ctx, opt, bat.client.PerformRequest)
+}
+
+// PerformFormRequest is like PerformRequest for multipart form requests.
+func (bat *BasicAccessToken) PerformFormRequest(ctx context.Context, opt PerformRequestOptions) (*Response, error) {
+	return bat.perform(ctx, opt, bat.client.PerformFormRequest)
+}
+
+func (bat *BasicAccessToken) perform(ctx context.Context, opt PerformRequestOptions, do func(context.Context, PerformRequestOptions) (*Response, error)) (*Response, error) {
+	at, err := bat.token(ctx, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "BasicAccessToken.PerformRequest")
+	}
+	res, err := do(ctx, withAccessToken(opt, at))
+	if err != nil || res == nil {
+		return res, err
+	}
+	ce := peekCommonError(res.Body)
+	// The token is invalid or expired, e.g. because it was rotated by another
+	// caller of cgi-bin/token.
+	if ce == nil || errCodeCategory(ce.ErrCode) != ErrCategoryToken {
+		return res, nil
+	}
+	bat.client.infof("wechat: access token rejected by %s with errcode %d, refreshing", opt.Endpoint, ce.ErrCode)
+	fresh, err := bat.fetch(ctx, at)
+	if err != nil {
+		return nil, errors.Wrap(err, "BasicAccessToken.PerformRequest")
+	}
+	return do(ctx, withAccessToken(opt, fresh.AccessToken))
+}
+
+// withAccessToken returns opt with a copy of its params that carries the
+// access token.
+func withAccessToken(opt PerformRequestOptions, accessToken string) PerformRequestOptions {
+	params := url.Values{}
+	for k, v := range opt.Params {
+		params[k] = v
+	}
+	params.Set("access_token", accessToken)
+	opt.Params = params
+	return opt
+}
+
+// performTokenRequest sends opt with the static accessToken, or with a
+// token of iat managed by BasicAccessToken if iat is set.
+func (c *Client) performTokenRequest(ctx context.Context, accessToken string, iat IAccessToken, opt PerformRequestOptions) (*Response, error) {
+	if iat != nil {
+		return c.BasicAccessToken(iat).PerformRequest(ctx, opt)
+	}
+	return c.PerformRequest(ctx, withAccessToken(opt, accessToken))
+}
+
+// performTokenFormRequest is like performTokenRequest for multipart form
+// requests.
+func (c *Client) performTokenFormRequest(ctx context.Context, accessToken string, iat IAccessToken, opt PerformRequestOptions) (*Response, error) {
+	if iat != nil {
+		return c.BasicAccessToken(iat).PerformFormRequest(ctx, opt)
+	}
+	return c.PerformFormRequest(ctx, withAccessToken(opt, accessToken))
 }
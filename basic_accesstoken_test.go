@@ -0,0 +1,232 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingAccessToken is an IAccessToken that counts Credentials calls.
+type countingAccessToken struct {
+	name      string
+	delay     time.Duration
+	expiresIn int64
+	calls     int32
+}
+
+func (cat *countingAccessToken) Credentials(ctx context.Context) (*AccessToken, error) {
+	n := atomic.AddInt32(&cat.calls, 1)
+	select {
+	case <-time.After(cat.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	expiresIn := cat.expiresIn
+	if expiresIn == 0 {
+		expiresIn = 7200
+	}
+	return &AccessToken{AccessToken: fmt.Sprintf("token%d", n), ExpiresIn: expiresIn}, nil
+}
+
+func (cat *countingAccessToken) ToString() string {
+	return cat.name
+}
+
+func TestBasicAccessToken_GetTokenSingleFlight(t *testing.T) {
+	client, err := NewClient()
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	iat := &countingAccessToken{name: "single-flight", delay: 50 * time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 20)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i] = client.BasicAccessToken(iat).GetToken(ctx, false)
+		}(i)
+	}
+	wg.Wait()
+	if calls := atomic.LoadInt32(&iat.calls); calls != 1 {
+		t.Log("Credentials should be called once", calls)
+		t.FailNow()
+	}
+	for _, token := range tokens {
+		if token != "token1" {
+			t.Log(tokens)
+			t.FailNow()
+		}
+	}
+}
+
+func TestBasicAccessToken_Invalidate(t *testing.T) {
+	client, err := NewClient()
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	iat := &countingAccessToken{name: "invalidate"}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	bat := client.BasicAccessToken(iat)
+	if token := bat.GetToken(ctx, false); token != "token1" {
+		t.Log(token)
+		t.FailNow()
+	}
+	if _, err := bat.meta(ctx); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	if err := bat.Invalidate(ctx); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	// the metadata of the dropped token must not drive the refresher
+	if meta, err := bat.meta(ctx); err == nil {
+		t.Log("metadata should be removed", meta)
+		t.FailNow()
+	}
+	if token := bat.GetToken(ctx, false); token != "token2" {
+		t.Log(token)
+		t.FailNow()
+	}
+}
+
+func TestBasicAccessToken_GetTokenLocker(t *testing.T) {
+	cache, err := NewMemCache()
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	locker := NewMemLocker()
+	iat := &countingAccessToken{name: "locker", delay: 20 * time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// Every client stands for a replica sharing cache and locker.
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		client, err := NewClient(SetCacheBackend(cache), SetLocker(locker))
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if token := client.BasicAccessToken(iat).GetToken(ctx, false); token != "token1" {
+				t.Log("unexpected token", token)
+				t.Fail()
+			}
+		}()
+	}
+	wg.Wait()
+	if calls := atomic.LoadInt32(&iat.calls); calls != 1 {
+		t.Log("Credentials should be called once", calls)
+		t.FailNow()
+	}
+}
+
+func TestMemLocker_Lock(t *testing.T) {
+	ml := NewMemLocker()
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	token, err := ml.Lock(ctx, "key", time.Minute)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	short, shortCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer shortCancel()
+	if _, err := ml.Lock(short, "key", time.Minute); err != context.DeadlineExceeded {
+		t.Log("lock should be held", err)
+		t.FailNow()
+	}
+	if err := ml.Unlock(ctx, "key", "other"); err != ErrLockNotHeld {
+		t.Log(err)
+		t.FailNow()
+	}
+	if err := ml.Unlock(ctx, "key", token); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	// An expired lease releases the lock.
+	if _, err := ml.Lock(ctx, "key", 10*time.Millisecond); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	if _, err := ml.Lock(ctx, "key", time.Minute); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+}
+
+func TestClient_RegisterAccessToken(t *testing.T) {
+	client, err := NewClient(SetRefreshFraction(0.5))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	iat := &countingAccessToken{name: "refresher", expiresIn: 2}
+	client.RegisterAccessToken(iat)
+	client.RegisterAccessToken(iat)
+	time.Sleep(2500 * time.Millisecond)
+	client.Stop()
+
+	calls := atomic.LoadInt32(&iat.calls)
+	if calls < 2 || calls > 3 {
+		t.Log("token should be refreshed once or twice after the first fetch", calls)
+		t.FailNow()
+	}
+	time.Sleep(1200 * time.Millisecond)
+	if atomic.LoadInt32(&iat.calls) != calls {
+		t.Log("refresher should be stopped")
+		t.FailNow()
+	}
+}
+
+func TestStableAccessToken_ForceRefresh(t *testing.T) {
+	var forced []bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := new(StableAccessTokenBody)
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil || r.URL.Path != "/"+StableAccessTokenEndpoint {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		forced = append(forced, body.ForceRefresh)
+		fmt.Fprintf(w, `{"access_token":"token%d","expires_in":7200}`, len(forced))
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(MiniProgramHost, ts.URL))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	bat := client.BasicAccessToken(client.MiniProgramStableAccessToken().SetAppID("appid").SetSecret("secret"))
+	if token := bat.GetToken(ctx, false); token != "token1" {
+		t.Log(token)
+		t.FailNow()
+	}
+	if token := bat.RefreshToken(ctx); token != "token2" {
+		t.Log(token)
+		t.FailNow()
+	}
+	if len(forced) != 2 || forced[0] || !forced[1] {
+		t.Log("only RefreshToken should force refresh", forced)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,251 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MediaGetEndpoint Endpoint
+	MediaGetEndpoint = "cgi-bin/media/get"
+	// MediaUploadEndpoint Endpoint
+	MediaUploadEndpoint = "cgi-bin/media/upload"
+)
+
+// MediaGet 获取临时素材，公众号和企业微信通用。
+// The media is streamed, so it can be piped to storage without buffering.
+type MediaGet struct {
+	client  *Client
+	baseURI string
+
+	accessToken string
+	iat         IAccessToken
+	mediaID     string
+}
+
+// NewMediaGet return instance of MediaGet, baseURI is OfficeAccountHost or
+// WorkHost.
+func NewMediaGet(client *Client, baseURI string) *MediaGet {
+	mg := &MediaGet{
+		client:  client,
+		baseURI: baseURI,
+	}
+	return mg
+}
+
+// SetAccessToken SetAccessToken
+func (mg *MediaGet) SetAccessToken(accessToken string) *MediaGet {
+	mg.accessToken = accessToken
+	return mg
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (mg *MediaGet) SetAccessTokenSource(iat IAccessToken) *MediaGet {
+	mg.iat = iat
+	return mg
+}
+
+// SetMediaID SetMediaID
+func (mg *MediaGet) SetMediaID(mediaID string) *MediaGet {
+	mg.mediaID = mediaID
+	return mg
+}
+
+// Validate checks if the operation is valid.
+func (mg *MediaGet) Validate() error {
+	var invalid []string
+	if mg.baseURI == "" {
+		invalid = append(invalid, "baseURI")
+	}
+	if mg.mediaID == "" {
+		invalid = append(invalid, "media_id")
+	}
+	if mg.accessToken == "" && mg.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do returns the media, the caller must close MediaGetResponse.Body. For
+// videos of office accounts, wechat answers with VideoURL instead.
+func (mg *MediaGet) Do(ctx context.Context) (*MediaGetResponse, error) {
+	// Check pre-conditions
+	if err := mg.Validate(); err != nil {
+		return nil, errors.Wrap(err, "MediaGet.Do")
+	}
+	// url params
+	params := url.Values{}
+	params.Set("media_id", mg.mediaID)
+	// PerformRequest
+	res, err := mg.client.performTokenRequest(ctx, mg.accessToken, mg.iat, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   params,
+		BaseURI:  mg.baseURI,
+		Endpoint: MediaGetEndpoint,
+		Stream:   true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "MediaGet.Do")
+	}
+	// Return operation response
+	ret := new(MediaGetResponse)
+	if res.Stream == nil {
+		if err := mg.client.decodeResponse(MediaGetEndpoint, res, ret); err != nil {
+			return ret, errors.Wrap(err, "MediaGet.Do")
+		}
+		return ret, nil
+	}
+	ret.ContentType = res.ContentType()
+	ret.Filename = res.Filename()
+	ret.ContentLength = -1
+	if n, err := strconv.ParseInt(res.Header.Get("Content-Length"), 10, 64); err == nil {
+		ret.ContentLength = n
+	}
+	ret.Body = res.Stream
+	return ret, nil
+}
+
+// MediaGetResponse MediaGetResponse
+type MediaGetResponse struct {
+	CommonError
+	VideoURL string `json:"video_url"`
+
+	ContentType   string        `json:"-"`
+	Filename      string        `json:"-"`
+	ContentLength int64         `json:"-"` // -1 if unknown
+	Body          io.ReadCloser `json:"-"` // nil if wechat answered with JSON
+}
+
+// Close closes the Body of the media.
+func (mgr *MediaGetResponse) Close() error {
+	if mgr.Body == nil {
+		return nil
+	}
+	return mgr.Body.Close()
+}
+
+// MediaUpload 上传临时素材，公众号和企业微信通用。
+// The file is streamed from its reader.
+type MediaUpload struct {
+	client  *Client
+	baseURI string
+
+	accessToken string
+	iat         IAccessToken
+	mediaType   string
+	fileName    string
+	contentType string
+	reader      io.Reader
+	size        int64
+}
+
+// NewMediaUpload return instance of MediaUpload, baseURI is
+// OfficeAccountHost or WorkHost.
+func NewMediaUpload(client *Client, baseURI string) *MediaUpload {
+	mu := &MediaUpload{
+		client:  client,
+		baseURI: baseURI,
+	}
+	return mu
+}
+
+// SetAccessToken SetAccessToken
+func (mu *MediaUpload) SetAccessToken(accessToken string) *MediaUpload {
+	mu.accessToken = accessToken
+	return mu
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (mu *MediaUpload) SetAccessTokenSource(iat IAccessToken) *MediaUpload {
+	mu.iat = iat
+	return mu
+}
+
+// SetType 媒体文件类型，公众号：image、voice、video、thumb，企业微信：image、voice、video、file
+func (mu *MediaUpload) SetType(mediaType string) *MediaUpload {
+	mu.mediaType = mediaType
+	return mu
+}
+
+// SetMedia sets the file read from r, size is its length. Use an io.Seeker
+// like *os.File if the upload should be retried.
+func (mu *MediaUpload) SetMedia(fileName, contentType string, r io.Reader, size int64) *MediaUpload {
+	mu.fileName = fileName
+	mu.contentType = contentType
+	mu.reader = r
+	mu.size = size
+	return mu
+}
+
+// Validate checks if the operation is valid.
+func (mu *MediaUpload) Validate() error {
+	var invalid []string
+	if mu.baseURI == "" {
+		invalid = append(invalid, "baseURI")
+	}
+	if mu.mediaType == "" {
+		invalid = append(invalid, "type")
+	}
+	if mu.reader == nil || mu.fileName == "" {
+		invalid = append(invalid, "media")
+	}
+	if mu.accessToken == "" && mu.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (mu *MediaUpload) Do(ctx context.Context) (*MediaUploadResponse, error) {
+	// Check pre-conditions
+	if err := mu.Validate(); err != nil {
+		return nil, errors.Wrap(err, "MediaUpload.Do")
+	}
+	// url params
+	params := url.Values{}
+	params.Set("type", mu.mediaType)
+	// WeCom requires the filelength of the media
+	body := NewMultipart().SetFileLength(mu.baseURI == WorkHost).
+		AddFile("media", mu.fileName, mu.contentType, mu.reader, mu.size)
+	// PerformRequest
+	res, err := mu.client.performTokenFormRequest(ctx, mu.accessToken, mu.iat, PerformRequestOptions{
+		Method:    http.MethodPost,
+		Params:    params,
+		Multipart: body,
+		BaseURI:   mu.baseURI,
+		Endpoint:  MediaUploadEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "MediaUpload.Do")
+	}
+	// Return operation response
+	ret := new(MediaUploadResponse)
+	if err := mu.client.decodeResponse(MediaUploadEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "MediaUpload.Do")
+	}
+	return ret, nil
+}
+
+// MediaUploadResponse MediaUploadResponse
+type MediaUploadResponse struct {
+	CommonError
+	Type         string      `json:"type"`
+	MediaID      string      `json:"media_id"`
+	ThumbMediaID string      `json:"thumb_media_id"` // 公众号 thumb 类型
+	CreatedAt    json.Number `json:"created_at"`     // 企业微信返回字符串
+}
@@ -0,0 +1,116 @@
+package wechat
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMediaGet_Do(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("media_id") {
+		case "image":
+			w.Header().Set("Content-Type", "image/jpeg")
+			w.Header().Set("Content-Disposition", `attachment; filename="MEDIA_ID.jpg"`)
+			w.Write([]byte("jpeg"))
+		case "video":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(`{"video_url":"http://example.com/video"}`))
+		default:
+			w.Write([]byte(`{"errcode":40007,"errmsg":"invalid media_id"}`))
+		}
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(OfficeAccountHost, ts.URL))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	tests := []struct {
+		name     string
+		mediaID  string
+		wantBody string
+		wantURL  string
+		wantErr  bool
+	}{
+		{name: "stream", mediaID: "image", wantBody: "jpeg"},
+		{name: "video url", mediaID: "video", wantURL: "http://example.com/video"},
+		{name: "error", mediaID: "none", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			ret, err := client.OfficeAccountMediaGet().SetAccessToken("token").SetMediaID(tt.mediaID).Do(ctx)
+			if tt.wantErr {
+				if !IsErrCode(err, ErrCodeInvalidMediaID) {
+					t.Log(err)
+					t.FailNow()
+				}
+				return
+			}
+			if err != nil || ret.VideoURL != tt.wantURL {
+				t.Log(ret, err)
+				t.FailNow()
+			}
+			defer ret.Close()
+			if tt.wantBody == "" {
+				return
+			}
+			body, err := ioutil.ReadAll(ret.Body)
+			if err != nil || string(body) != tt.wantBody || ret.Filename != "MEDIA_ID.jpg" || ret.ContentType != "image/jpeg" {
+				t.Log(string(body), ret, err)
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestMediaUpload_Do(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, fh, err := r.FormFile("media")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// the filelength is echoed as media_id
+		mediaID := "none"
+		for _, param := range strings.Split(fh.Header.Get("Content-Disposition"), ";") {
+			if kv := strings.SplitN(strings.TrimSpace(param), "=", 2); kv[0] == "filelength" {
+				mediaID = kv[1]
+			}
+		}
+		w.Write([]byte(`{"type":"image","media_id":"` + mediaID + `"}`))
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURLs(map[string]string{OfficeAccountHost: ts.URL, WorkHost: ts.URL}))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	tests := []struct {
+		name        string
+		upload      *MediaUpload
+		wantMediaID string
+	}{
+		{name: "office account", upload: client.OfficeAccountMediaUpload(), wantMediaID: "none"},
+		{name: "work", upload: client.WorkMediaUpload(), wantMediaID: "4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			ret, err := tt.upload.SetAccessToken("token").SetType("image").
+				SetMedia("image.jpg", "image/jpeg", bytes.NewReader([]byte("jpeg")), 4).Do(ctx)
+			if err != nil || ret.MediaID != tt.wantMediaID {
+				t.Log(ret, err)
+				t.FailNow()
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package wechat
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"net/url"
 
@@ -12,7 +11,14 @@ import (
 
 // err
 var (
-	ErrUserRefuseToAccept = errors.New("user refuse to accept the msg")
+	// ErrUserRefuseToAccept matches the *APIError returned by Send if the user
+	// refused to accept the msg, use errors.Is or IsUserRefused.
+	//
+	// Breaking change: Send used to return ErrUserRefuseToAccept itself, now
+	// it returns a new *APIError, so checks like err == ErrUserRefuseToAccept
+	// no longer match and must be replaced with
+	// errors.Is(err, ErrUserRefuseToAccept).
+	ErrUserRefuseToAccept error = &APIError{ErrCode: ErrCodeUserRefuseToAccept, ErrMsg: "user refuse to accept the msg"}
 )
 
 // IBasicMessage 发送消息的接口，不同消息只需要实现该接口即可
@@ -47,20 +53,15 @@ func (bm *BasicMessage) Send(ctx context.Context) error {
 	if err := bm.message.Validate(); err != nil {
 		return errors.Wrap(err, "BasicMessage.Send Validate")
 	}
-	// accessToken
-	at := bm.client.BasicAccessToken(bm.accessToken).GetToken(ctx, false)
-	// url params
-	params := bm.message.Params()
-	params.Set("access_token", at)
 	// body
 	bodybyte, err := json.Marshal(bm.message.Body())
 	if err != nil {
 		return errors.Wrap(err, "BasicMessage.Send.json.Marshal")
 	}
-	// PerformRequest
-	res, err := bm.client.PerformRequest(ctx, PerformRequestOptions{
+	// PerformRequest, access token is managed by BasicAccessToken
+	res, err := bm.client.BasicAccessToken(bm.accessToken).PerformRequest(ctx, PerformRequestOptions{
 		Method:   http.MethodPost,
-		Params:   params,
+		Params:   bm.message.Params(),
 		Body:     string(bodybyte),
 		BaseURI:  bm.message.BaseURI(),
 		Endpoint: bm.message.Endpoint(),
@@ -73,11 +74,8 @@ func (bm *BasicMessage) Send(ctx context.Context) error {
 	if err := bm.client.decoder.Decode(res.Body, ret); err != nil {
 		return errors.Wrap(err, "BasicMessage.Send.Decode")
 	}
-	if ret.ErrCode != 0 {
-		if ret.ErrCode == 43101 {
-			return ErrUserRefuseToAccept
-		}
-		err = errors.Wrap(fmt.Errorf("code: %d, msg: %v", ret.ErrCode, ret.ErrMsg), "BasicMessage.Send")
+	if err := DecodeWithCommonError(bm.message.Endpoint(), *ret); err != nil {
+		return errors.Wrap(err, "BasicMessage.Send")
 	}
-	return err
+	return nil
 }
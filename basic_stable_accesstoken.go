@@ -0,0 +1,170 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// StableAccessTokenEndpoint Endpoint
+	StableAccessTokenEndpoint = "cgi-bin/stable_token"
+)
+
+// StableAccessToken 稳定版接口调用凭据，公众号和小程序通用。
+// Unlike cgi-bin/token, a stable token isn't invalidated when someone else
+// fetches one, so several services may share an appid. Used as IAccessToken,
+// GetToken fetches in normal mode and RefreshToken with force_refresh.
+type StableAccessToken struct {
+	client  *Client
+	baseURI string
+
+	appid        string
+	secret       string
+	grantType    string
+	forceRefresh bool
+}
+
+// NewStableAccessToken return instance of StableAccessToken, baseURI is
+// OfficeAccountHost or MiniProgramHost.
+func NewStableAccessToken(client *Client, baseURI string) *StableAccessToken {
+	sat := &StableAccessToken{
+		client:  client,
+		baseURI: baseURI,
+	}
+	sat.SetGrantType()
+	return sat
+}
+
+// SetSecret SetSecret
+func (sat *StableAccessToken) SetSecret(secret string) *StableAccessToken {
+	sat.secret = secret
+	return sat
+}
+
+// SetAppID SetAppID
+func (sat *StableAccessToken) SetAppID(appid string) *StableAccessToken {
+	sat.appid = appid
+	return sat
+}
+
+// SetGrantType SetGrantType
+func (sat *StableAccessToken) SetGrantType() *StableAccessToken {
+	sat.grantType = "client_credential"
+	return sat
+}
+
+// SetForceRefresh 强制刷新模式，Do 会使之前的 stable token 失效。
+func (sat *StableAccessToken) SetForceRefresh(forceRefresh bool) *StableAccessToken {
+	sat.forceRefresh = forceRefresh
+	return sat
+}
+
+// Validate checks if the operation is valid.
+func (sat *StableAccessToken) Validate() error {
+	var invalid []string
+	if sat.baseURI == "" {
+		invalid = append(invalid, "baseURI")
+	}
+	if sat.appid == "" {
+		invalid = append(invalid, "appid")
+	}
+	if sat.secret == "" {
+		invalid = append(invalid, "secret")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (sat *StableAccessToken) Do(ctx context.Context) (*StableAccessTokenResponse, error) {
+	return sat.do(ctx, sat.forceRefresh)
+}
+
+func (sat *StableAccessToken) do(ctx context.Context, forceRefresh bool) (*StableAccessTokenResponse, error) {
+	// Check pre-conditions
+	if err := sat.Validate(); err != nil {
+		return nil, errors.Wrap(err, "StableAccessToken.Do")
+	}
+	bodybyte, err := json.Marshal(&StableAccessTokenBody{
+		GrantType:    sat.grantType,
+		AppID:        sat.appid,
+		Secret:       sat.secret,
+		ForceRefresh: forceRefresh,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "StableAccessToken.Do")
+	}
+	// url params
+	params := url.Values{}
+	// PerformRequest
+	res, err := sat.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   params,
+		Body:     string(bodybyte),
+		BaseURI:  sat.baseURI,
+		Endpoint: StableAccessTokenEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "StableAccessToken.Do")
+	}
+	// Return operation response
+	ret := new(StableAccessTokenResponse)
+	if err := sat.client.decodeResponse(StableAccessTokenEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "StableAccessToken.Do")
+	}
+	return ret, nil
+}
+
+// Credentials fetches the token in normal mode, which returns the current
+// token as long as it is valid.
+func (sat *StableAccessToken) Credentials(ctx context.Context) (*AccessToken, error) {
+	return sat.credentials(ctx, false)
+}
+
+// ForceCredentials fetches the token with force_refresh, which replaces the
+// current token.
+func (sat *StableAccessToken) ForceCredentials(ctx context.Context) (*AccessToken, error) {
+	return sat.credentials(ctx, true)
+}
+
+func (sat *StableAccessToken) credentials(ctx context.Context, forceRefresh bool) (*AccessToken, error) {
+	res, err := sat.do(ctx, forceRefresh)
+	if err != nil {
+		return nil, errors.Wrap(err, "StableAccessToken.Credentials")
+	}
+	if err := DecodeWithCommonError(StableAccessTokenEndpoint, res.CommonError); err != nil {
+		return nil, errors.Wrap(err, "StableAccessToken.Credentials")
+	}
+	at := &AccessToken{
+		AccessToken: res.AccessToken,
+		ExpiresIn:   res.ExpiresIn,
+	}
+	return at, nil
+}
+
+// ToString ToString
+func (sat *StableAccessToken) ToString() string {
+	return fmt.Sprintf("stable_%s_%s_%s", sat.grantType, sat.appid, sat.secret)
+}
+
+// StableAccessTokenBody StableAccessTokenBody
+type StableAccessTokenBody struct {
+	GrantType    string `json:"grant_type"`
+	AppID        string `json:"appid"`
+	Secret       string `json:"secret"`
+	ForceRefresh bool   `json:"force_refresh"`
+}
+
+// StableAccessTokenResponse StableAccessTokenResponse
+type StableAccessTokenResponse struct {
+	CommonError
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
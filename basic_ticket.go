@@ -0,0 +1,196 @@
+package wechat
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Endpoint
+const (
+	TicketEndpoint          = "cgi-bin/ticket/getticket" // 公众号
+	WorkJSAPITicketEndpoint = "cgi-bin/get_jsapi_ticket" // 企业微信企业的 jsapi_ticket
+	WorkAgentTicketEndpoint = "cgi-bin/ticket/get"       // 企业微信应用的 jsapi_ticket
+)
+
+// ticket types
+const (
+	TicketTypeJSAPI       = "jsapi"
+	TicketTypeWxCard      = "wx_card"
+	TicketTypeAgentConfig = "agent_config"
+)
+
+// Ticket 公众号的 jsapi_ticket、wx_card api_ticket，企业微信企业和应用的
+// jsapi_ticket. It implements IAccessToken, so tickets are cached and
+// refreshed by BasicAccessToken like access tokens, see GetTicket.
+type Ticket struct {
+	client  *Client
+	baseURI string
+
+	iat        IAccessToken
+	ticketType string
+	appid      string
+}
+
+// NewTicket return instance of Ticket, baseURI is OfficeAccountHost or
+// WorkHost. iat is the source of the access token the ticket is issued
+// for.
+func NewTicket(client *Client, baseURI string, iat IAccessToken, ticketType string) *Ticket {
+	t := &Ticket{
+		client:     client,
+		baseURI:    baseURI,
+		iat:        iat,
+		ticketType: ticketType,
+	}
+	return t
+}
+
+// SetAppID sets the appid, or corpid of WeCom, used by JSSDKConfig.
+func (t *Ticket) SetAppID(appid string) *Ticket {
+	t.appid = appid
+	return t
+}
+
+// Validate checks if the operation is valid.
+func (t *Ticket) Validate() error {
+	var invalid []string
+	if t.baseURI == "" {
+		invalid = append(invalid, "baseURI")
+	}
+	if t.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if t.ticketType == "" {
+		invalid = append(invalid, "type")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// endpoint returns the endpoint and params of the ticket type.
+func (t *Ticket) endpoint() (string, url.Values) {
+	params := url.Values{}
+	if t.baseURI == WorkHost {
+		if t.ticketType == TicketTypeAgentConfig {
+			params.Set("type", t.ticketType)
+			return WorkAgentTicketEndpoint, params
+		}
+		return WorkJSAPITicketEndpoint, params
+	}
+	params.Set("type", t.ticketType)
+	return TicketEndpoint, params
+}
+
+// Do Do
+func (t *Ticket) Do(ctx context.Context) (*TicketResponse, error) {
+	// Check pre-conditions
+	if err := t.Validate(); err != nil {
+		return nil, errors.Wrap(err, "Ticket.Do")
+	}
+	endpoint, params := t.endpoint()
+	// PerformRequest
+	res, err := t.client.BasicAccessToken(t.iat).PerformRequest(ctx, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   params,
+		BaseURI:  t.baseURI,
+		Endpoint: endpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Ticket.Do")
+	}
+	// Return operation response
+	ret := new(TicketResponse)
+	if err := t.client.decodeResponse(endpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "Ticket.Do")
+	}
+	return ret, nil
+}
+
+// Credentials Credentials
+func (t *Ticket) Credentials(ctx context.Context) (*AccessToken, error) {
+	res, err := t.Do(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Ticket.Credentials")
+	}
+	endpoint, _ := t.endpoint()
+	if err := DecodeWithCommonError(endpoint, res.CommonError); err != nil {
+		return nil, errors.Wrap(err, "Ticket.Credentials")
+	}
+	at := &AccessToken{
+		AccessToken: res.Ticket,
+		ExpiresIn:   res.ExpiresIn,
+	}
+	return at, nil
+}
+
+// ToString ToString
+func (t *Ticket) ToString() string {
+	return fmt.Sprintf("ticket_%s_%s_%s", t.baseURI, t.ticketType, t.iat.ToString())
+}
+
+// GetTicket returns the cached ticket, or fetches a new one.
+func (t *Ticket) GetTicket(ctx context.Context) (string, error) {
+	if err := t.Validate(); err != nil {
+		return "", errors.Wrap(err, "Ticket.GetTicket")
+	}
+	ticket, err := t.client.BasicAccessToken(t).token(ctx, false)
+	if err != nil {
+		return "", errors.Wrap(err, "Ticket.GetTicket")
+	}
+	return ticket, nil
+}
+
+// JSSDKConfig returns the config of wx.config, or wx.agentConfig for
+// TicketTypeAgentConfig, for the page at pageURL. The fragment of pageURL
+// is ignored.
+func (t *Ticket) JSSDKConfig(ctx context.Context, pageURL string) (*JSSDKConfig, error) {
+	if t.ticketType == TicketTypeWxCard {
+		return nil, errors.New("Ticket.JSSDKConfig: wx_card tickets are for card signatures")
+	}
+	if t.appid == "" {
+		return nil, errors.New("Ticket.JSSDKConfig: missing required fields: [appid]")
+	}
+	ticket, err := t.GetTicket(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Ticket.JSSDKConfig")
+	}
+	cfg := &JSSDKConfig{
+		AppID:     t.appid,
+		Timestamp: time.Now().Unix(),
+		NonceStr:  string(RandomStr(16)),
+	}
+	cfg.Signature = JSSDKSignature(ticket, cfg.NonceStr, cfg.Timestamp, pageURL)
+	return cfg, nil
+}
+
+// TicketResponse TicketResponse
+type TicketResponse struct {
+	CommonError
+	Ticket    string `json:"ticket"`
+	ExpiresIn int64  `json:"expires_in"`
+}
+
+// JSSDKConfig is passed to wx.config in the page.
+type JSSDKConfig struct {
+	AppID     string `json:"appId"`
+	Timestamp int64  `json:"timestamp"`
+	NonceStr  string `json:"nonceStr"`
+	Signature string `json:"signature"`
+}
+
+// JSSDKSignature JS-SDK 使用权限签名算法
+func JSSDKSignature(ticket, nonceStr string, timestamp int64, pageURL string) string {
+	if i := strings.IndexByte(pageURL, '#'); i >= 0 {
+		pageURL = pageURL[:i]
+	}
+	// The params are sorted by key already, Signature sorts whole strings.
+	str := fmt.Sprintf("jsapi_ticket=%s&noncestr=%s&timestamp=%d&url=%s", ticket, nonceStr, timestamp, pageURL)
+	return Signature(str)
+}
@@ -0,0 +1,64 @@
+package wechat
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJSSDKSignature(t *testing.T) {
+	ticket := "sM4AOVdWfPE4DxkXGEs8VMCPGGVi4C3VM0P37wVUCFvkVAy_90u5h9nbSlYy3-Sl-HhTdfl2fzFy1AOcHKP7qg"
+	tests := []struct {
+		name    string
+		pageURL string
+	}{
+		{name: "url", pageURL: "http://mp.weixin.qq.com?params=value"},
+		{name: "fragment", pageURL: "http://mp.weixin.qq.com?params=value#/home"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := JSSDKSignature(ticket, "Wm3WZYTPz0wzccnW", 1414587457, tt.pageURL)
+			if got != "0f9de62fce790f9a083d5c99e95740ceb90c27ed" {
+				t.Log(got)
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestTicket_JSSDKConfig(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/"+TicketEndpoint || query.Get("type") != TicketTypeJSAPI || query.Get("access_token") != "token1" {
+			w.Write([]byte(`{"errcode":40001,"errmsg":"invalid credential"}`))
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok","ticket":"TICKET","expires_in":7200}`))
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(OfficeAccountHost, ts.URL))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	ticket := client.OfficeAccountJSAPITicket(&countingAccessToken{name: "jssdk"}).SetAppID("appid")
+	for i := 0; i < 2; i++ {
+		cfg, err := ticket.JSSDKConfig(ctx, "http://example.com/page#top")
+		if err != nil || cfg.AppID != "appid" || cfg.Signature != JSSDKSignature("TICKET", cfg.NonceStr, cfg.Timestamp, "http://example.com/page") {
+			t.Log(cfg, err)
+			t.FailNow()
+		}
+	}
+	// the ticket is cached
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Log(n)
+		t.FailNow()
+	}
+}
@@ -2,6 +2,7 @@ package wechat
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/patrickmn/go-cache"
@@ -27,6 +28,29 @@ var (
 	ErrCacheKeyNotExist = errors.New("key not exist")
 )
 
+// UnmarshalCacheValue stores a value read from a Cache in the value
+// pointed to by v. It works with values kept as they are, like in MemCache,
+// as well as with the JSON strings returned by RedisCache.
+func UnmarshalCacheValue(value interface{}, v interface{}) error {
+	var data []byte
+	switch val := value.(type) {
+	case string:
+		data = []byte(val)
+	case []byte:
+		data = val
+	default:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return errors.Wrap(err, "UnmarshalCacheValue")
+		}
+		data = b
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.Wrap(err, "UnmarshalCacheValue")
+	}
+	return nil
+}
+
 // MemCache 默认走内存 cache，accesstoken 量不大，内存可以
 type MemCache struct {
 	cache      *cache.Cache
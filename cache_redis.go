@@ -0,0 +1,163 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+)
+
+// const of redis cache
+const (
+	DefaultRedisKeyPrefix                  = "jony4:wechat:"
+	DefaultRedisLockInterval time.Duration = 50 * time.Millisecond
+)
+
+// redisUnlockScript deletes the lock only if it is still held with the
+// given token.
+var redisUnlockScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0
+`)
+
+// RedisCache 多进程共享 accesstoken 时使用 redis cache，同时实现了 Locker 接口。
+// Strings and []byte are stored as they are, other values are stored as
+// JSON. Get always returns a string, use UnmarshalCacheValue to decode it.
+type RedisCache struct {
+	client       redis.UniversalClient
+	prefix       string
+	lockInterval time.Duration
+}
+
+// RedisCacheOptFunc RedisCacheOptFunc
+type RedisCacheOptFunc func(*RedisCache) error
+
+// NewRedisCache NewRedisCache
+func NewRedisCache(client redis.UniversalClient, options ...RedisCacheOptFunc) (*RedisCache, error) {
+	if client == nil {
+		return nil, errors.New("NewRedisCache: nil redis client")
+	}
+	rc := &RedisCache{
+		client:       client,
+		prefix:       DefaultRedisKeyPrefix,
+		lockInterval: DefaultRedisLockInterval,
+	}
+	for _, option := range options {
+		if err := option(rc); err != nil {
+			return nil, errors.Wrap(err, "NewRedisCache.opt")
+		}
+	}
+	return rc, nil
+}
+
+// SetRedisKeyPrefix sets the prefix of all keys, e.g. to share a redis
+// database between environments. It is DefaultRedisKeyPrefix by default.
+func SetRedisKeyPrefix(prefix string) RedisCacheOptFunc {
+	return func(rc *RedisCache) error {
+		rc.prefix = prefix
+		return nil
+	}
+}
+
+// SetRedisLockInterval sets how often Lock retries to acquire a lock held
+// by someone else. It is DefaultRedisLockInterval by default.
+func SetRedisLockInterval(interval time.Duration) RedisCacheOptFunc {
+	return func(rc *RedisCache) error {
+		if interval <= 0 {
+			return errors.Errorf("invalid lock interval %v", interval)
+		}
+		rc.lockInterval = interval
+		return nil
+	}
+}
+
+func (rc *RedisCache) key(key string) string {
+	return rc.prefix + key
+}
+
+// Set Set
+func (rc *RedisCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	var data interface{}
+	switch v := value.(type) {
+	case string, []byte:
+		data = v
+	default:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return errors.Wrap(err, "RedisCache.Set")
+		}
+		data = b
+	}
+	// No expiration for DefaultExpiration and NoExpiration.
+	if expiration < 0 {
+		expiration = 0
+	}
+	if err := rc.client.Set(ctx, rc.key(key), data, expiration).Err(); err != nil {
+		return errors.Wrap(err, "RedisCache.Set")
+	}
+	return nil
+}
+
+// Get Get
+func (rc *RedisCache) Get(ctx context.Context, key string) (interface{}, error) {
+	value, err := rc.client.Get(ctx, rc.key(key)).Result()
+	if err == redis.Nil {
+		return nil, ErrCacheKeyNotExist
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "RedisCache.Get")
+	}
+	return value, nil
+}
+
+// Delete Delete
+func (rc *RedisCache) Delete(ctx context.Context, key string) error {
+	if err := rc.client.Del(ctx, rc.key(key)).Err(); err != nil {
+		return errors.Wrap(err, "RedisCache.Delete")
+	}
+	return nil
+}
+
+// Lock implements Locker with SET NX PX, polling until the lock is free.
+func (rc *RedisCache) Lock(ctx context.Context, key string, lease time.Duration) (string, error) {
+	token, err := lockToken()
+	if err != nil {
+		return "", errors.Wrap(err, "RedisCache.Lock")
+	}
+	ticker := time.NewTicker(rc.lockInterval)
+	defer ticker.Stop()
+	for {
+		ok, err := rc.client.SetNX(ctx, rc.key(key), token, lease).Result()
+		if err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			return "", errors.Wrap(err, "RedisCache.Lock")
+		}
+		if ok {
+			return token, nil
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Unlock implements Locker, the lock is only released if it is still held
+// with token.
+func (rc *RedisCache) Unlock(ctx context.Context, key, token string) error {
+	n, err := redisUnlockScript.Run(ctx, rc.client, []string{rc.key(key)}, token).Int()
+	if err != nil {
+		return errors.Wrap(err, "RedisCache.Unlock")
+	}
+	if n == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
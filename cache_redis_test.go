@@ -0,0 +1,151 @@
+package wechat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newTestRedisCache(t *testing.T, options ...RedisCacheOptFunc) (*RedisCache, *miniredis.Miniredis) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	t.Cleanup(mr.Close)
+	rc, err := NewRedisCache(redis.NewClient(&redis.Options{Addr: mr.Addr()}), options...)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	return rc, mr
+}
+
+func TestRedisCache_Set(t *testing.T) {
+	type args struct {
+		key        string
+		value      interface{}
+		expiration time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "string",
+			args: args{
+				key:        "key",
+				value:      "test",
+				expiration: DefaultCacheExpiration,
+			},
+			want: "test",
+		},
+		{
+			name: "struct",
+			args: args{
+				key:        "struct",
+				value:      &AccessToken{AccessToken: "token", ExpiresIn: 7200},
+				expiration: NoExpiration,
+			},
+			want: `{"access_token":"token","expires_in":7200}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, mr := newTestRedisCache(t)
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			if err := rc.Set(ctx, tt.args.key, tt.args.value, tt.args.expiration); err != nil {
+				t.Log(err)
+				t.FailNow()
+			}
+			value, err := rc.Get(ctx, tt.args.key)
+			if err != nil || value.(string) != tt.want {
+				t.Log(value, err)
+				t.FailNow()
+			}
+			if !mr.Exists(DefaultRedisKeyPrefix + tt.args.key) {
+				t.Log("key should be prefixed")
+				t.FailNow()
+			}
+			rc.Delete(ctx, tt.args.key)
+			if _, err := rc.Get(ctx, tt.args.key); err != ErrCacheKeyNotExist {
+				t.Log("value should be deleted", err)
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestRedisCache_Expiration(t *testing.T) {
+	rc, mr := newTestRedisCache(t, SetRedisKeyPrefix("test:"))
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	if err := rc.Set(ctx, "key", "value", time.Minute); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	if ttl := mr.TTL("test:key"); ttl != time.Minute {
+		t.Log(ttl)
+		t.FailNow()
+	}
+	mr.FastForward(2 * time.Minute)
+	if _, err := rc.Get(ctx, "key"); err != ErrCacheKeyNotExist {
+		t.Log("value should be expired", err)
+		t.FailNow()
+	}
+}
+
+func TestRedisCache_UnmarshalCacheValue(t *testing.T) {
+	rc, _ := newTestRedisCache(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	want := AccessToken{AccessToken: "token", ExpiresIn: 7200}
+	if err := rc.Set(ctx, "key", want, DefaultExpiration); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	value, err := rc.Get(ctx, "key")
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var got AccessToken
+	if err := UnmarshalCacheValue(value, &got); err != nil || got != want {
+		t.Log(got, err)
+		t.FailNow()
+	}
+}
+
+func TestRedisCache_Lock(t *testing.T) {
+	rc, mr := newTestRedisCache(t, SetRedisLockInterval(time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	token, err := rc.Lock(ctx, "lock", time.Minute)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	short, shortCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer shortCancel()
+	if _, err := rc.Lock(short, "lock", time.Minute); err != context.DeadlineExceeded {
+		t.Log("lock should be held", err)
+		t.FailNow()
+	}
+	if err := rc.Unlock(ctx, "lock", "other"); err != ErrLockNotHeld {
+		t.Log(err)
+		t.FailNow()
+	}
+	if err := rc.Unlock(ctx, "lock", token); err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	if mr.Exists(DefaultRedisKeyPrefix + "lock") {
+		t.Log("lock should be released")
+		t.FailNow()
+	}
+}
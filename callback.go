@@ -0,0 +1,736 @@
+package wechat
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CallbackMode 消息加解密方式
+type CallbackMode int
+
+// callback modes
+const (
+	CallbackModePlaintext  CallbackMode = iota // 明文模式
+	CallbackModeCompatible                     // 兼容模式，明文和密文都可以处理
+	CallbackModeSafe                           // 安全模式，只接收密文
+)
+
+// message types of callbacks
+const (
+	MsgTypeText       = "text"
+	MsgTypeImage      = "image"
+	MsgTypeVoice      = "voice"
+	MsgTypeVideo      = "video"
+	MsgTypeShortVideo = "shortvideo"
+	MsgTypeLocation   = "location"
+	MsgTypeLink       = "link"
+	MsgTypeEvent      = "event"
+)
+
+// events of callbacks
+const (
+	EventSubscribe             = "subscribe"
+	EventUnsubscribe           = "unsubscribe"
+	EventScan                  = "SCAN"
+	EventLocation              = "LOCATION"
+	EventClick                 = "CLICK"
+	EventView                  = "VIEW"
+	EventTemplateSendJobFinish = "TEMPLATESENDJOBFINISH"
+)
+
+// maxCallbackBodySize limits the body of callback requests.
+const maxCallbackBodySize = 1 << 20
+
+// ErrCallbackSignature is returned if a callback request is not signed by
+// wechat.
+var ErrCallbackSignature = errors.New("wechat: invalid callback signature")
+
+// CallbackMessageHeader 回调消息的公共字段
+type CallbackMessageHeader struct {
+	ToUserName   string `xml:"ToUserName" json:"ToUserName"`
+	FromUserName string `xml:"FromUserName" json:"FromUserName"`
+	CreateTime   int64  `xml:"CreateTime" json:"CreateTime"`
+	MsgType      string `xml:"MsgType" json:"MsgType"`
+	Event        string `xml:"Event,omitempty" json:"Event,omitempty"`
+}
+
+// MessageHeader returns the common fields of the message.
+func (h *CallbackMessageHeader) MessageHeader() *CallbackMessageHeader {
+	return h
+}
+
+// callbackEnvelope is the body of an encrypted callback request.
+type callbackEnvelope struct {
+	XMLName    xml.Name `xml:"xml" json:"-"`
+	ToUserName string   `xml:"ToUserName" json:"ToUserName"`
+	AgentID    string   `xml:"AgentID" json:"-"`
+	Encrypt    string   `xml:"Encrypt" json:"Encrypt"`
+}
+
+// CDATA is a string marshaled as a CDATA section.
+type CDATA string
+
+// MarshalXML MarshalXML
+func (c CDATA) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(struct {
+		Value string `xml:",cdata"`
+	}{string(c)}, start)
+}
+
+// EncryptedReply is the body of an encrypted passive reply.
+type EncryptedReply struct {
+	XMLName      xml.Name `xml:"xml" json:"-"`
+	Encrypt      CDATA    `xml:"Encrypt" json:"Encrypt"`
+	MsgSignature CDATA    `xml:"MsgSignature" json:"MsgSignature"`
+	TimeStamp    int64    `xml:"TimeStamp" json:"TimeStamp"`
+	Nonce        CDATA    `xml:"Nonce" json:"Nonce"`
+}
+
+// RawReply is a passive reply sent as it is, even if the message was
+// encrypted.
+type RawReply string
+
+// replies that don't send a message to the user
+const (
+	// ReplySuccess tells wechat the message is received, e.g. when it is
+	// handled asynchronously, so wechat neither retries nor warns the user.
+	ReplySuccess RawReply = "success"
+	// ReplyEmpty is an empty reply, which wechat takes like ReplySuccess.
+	ReplyEmpty RawReply = ""
+)
+
+// EncryptReply encrypts the passive reply for safe mode and signs it with
+// a new timestamp and nonce. reply is marshaled like the replies of the
+// handlers of OfficeAccountServer.
+func EncryptReply(reply interface{}, token, aesKey, appID string) (*EncryptedReply, error) {
+	out, err := marshalCallbackReply(reply, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "EncryptReply")
+	}
+	cc := &callbackCrypto{token: token, aesKey: aesKey, receiveID: appID}
+	sealed, err := cc.encrypt(out)
+	if err != nil {
+		return nil, errors.Wrap(err, "EncryptReply")
+	}
+	return sealed, nil
+}
+
+// callbackCrypto verifies and decrypts callback requests and encrypts
+// replies, see 消息加解密说明.
+type callbackCrypto struct {
+	token      string
+	aesKey     string   // EncodingAESKey
+	receiveID  string   // appid, or corpid of WeCom
+	receiveIDs []string // other ids accepted when decrypting, e.g. suiteid
+}
+
+// verify checks the signature of timestamp, nonce and, for msg_signature,
+// the encrypted message.
+func (cc *callbackCrypto) verify(signature, timestamp, nonce string, encrypt ...string) bool {
+	params := append([]string{cc.token, timestamp, nonce}, encrypt...)
+	return subtle.ConstantTimeCompare([]byte(Signature(params...)), []byte(signature)) == 1
+}
+
+// decrypt decrypts the Encrypt field of a request.
+func (cc *callbackCrypto) decrypt(encrypt string) ([]byte, error) {
+	if cc.aesKey == "" {
+		return nil, errors.New("callbackCrypto.decrypt: missing EncodingAESKey")
+	}
+	_, raw, receiveID, err := DecryptMsgReceiveID(encrypt, cc.aesKey)
+	if err != nil {
+		return nil, err
+	}
+	if receiveID == cc.receiveID {
+		return raw, nil
+	}
+	for _, id := range cc.receiveIDs {
+		if receiveID == id {
+			return raw, nil
+		}
+	}
+	return nil, fmt.Errorf("callbackCrypto.decrypt: unexpected receiveid %q", receiveID)
+}
+
+// encrypt encrypts the reply raw, signed with a new timestamp and nonce.
+func (cc *callbackCrypto) encrypt(raw []byte) (*EncryptedReply, error) {
+	encrypted, err := EncryptMsg(RandomStr(16), raw, cc.receiveID, cc.aesKey)
+	if err != nil {
+		return nil, err
+	}
+	timestamp := time.Now().Unix()
+	nonce := string(RandomStr(10))
+	reply := &EncryptedReply{
+		Encrypt:      CDATA(encrypted),
+		MsgSignature: CDATA(Signature(cc.token, strconv.FormatInt(timestamp, 10), nonce, string(encrypted))),
+		TimeStamp:    timestamp,
+		Nonce:        CDATA(nonce),
+	}
+	return reply, nil
+}
+
+// readCallbackBody reads the body of a callback request.
+func readCallbackBody(r *http.Request) ([]byte, error) {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxCallbackBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxCallbackBodySize {
+		return nil, fmt.Errorf("callback body is larger than %d bytes", maxCallbackBodySize)
+	}
+	return body, nil
+}
+
+// isJSONMessage returns true if the message raw is JSON, not XML.
+func isJSONMessage(raw []byte) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && raw[0] == '{'
+}
+
+// marshalCallbackReply returns the body of reply: RawReply, string and
+// []byte are taken as they are, anything else is marshaled to JSON if
+// isJSON is true, to XML otherwise.
+func marshalCallbackReply(reply interface{}, isJSON bool) ([]byte, error) {
+	switch v := reply.(type) {
+	case RawReply:
+		return []byte(v), nil
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	}
+	if isJSON {
+		return json.Marshal(reply)
+	}
+	return xml.Marshal(reply)
+}
+
+// callbackMessage is implemented by the messages of all callback servers.
+type callbackMessage interface {
+	MessageHeader() *CallbackMessageHeader
+}
+
+// callbackHandler handles a decoded message and returns the reply.
+type callbackHandler func(ctx context.Context, msg callbackMessage) (interface{}, error)
+
+// callbackServer is the part shared by the callback servers: it answers
+// the URL verification, verifies and decrypts the requests, routes the
+// decoded messages to the handlers and encrypts the replies.
+type callbackServer struct {
+	client *Client
+	name   string // for logs
+
+	crypto      callbackCrypto
+	receiveName string // name of crypto.receiveID for Validate
+	mode        CallbackMode
+	wecom       bool // signed by msg_signature only, always encrypted
+	decode      func(raw []byte) (callbackMessage, error)
+	handlers    map[string]callbackHandler
+	fallback    callbackHandler
+	dedupWindow time.Duration
+	inflight    *callbackInflight
+	pool        *callbackPool // nil unless asynchronous
+}
+
+func newCallbackServer(client *Client, name, receiveName string, decode func(raw []byte) (callbackMessage, error)) callbackServer {
+	return callbackServer{
+		client:      client,
+		name:        name,
+		receiveName: receiveName,
+		mode:        CallbackModePlaintext,
+		decode:      decode,
+		handlers:    make(map[string]callbackHandler),
+		dedupWindow: DefaultCallbackDedupWindow,
+		inflight:    &callbackInflight{done: make(map[string]chan struct{})},
+	}
+}
+
+// validate checks if the server is valid.
+func (cs *callbackServer) validate() error {
+	var invalid []string
+	if cs.crypto.token == "" {
+		invalid = append(invalid, "token")
+	}
+	if cs.mode != CallbackModePlaintext || cs.wecom {
+		if cs.crypto.receiveID == "" {
+			invalid = append(invalid, cs.receiveName)
+		}
+		if cs.crypto.aesKey == "" {
+			invalid = append(invalid, "EncodingAESKey")
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// serveHTTP serves a callback request.
+func (cs *callbackServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := cs.validate(); err != nil {
+		cs.client.errorf("%s err: %v", cs.name, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	query := r.URL.Query()
+	if !cs.wecom && !cs.crypto.verify(query.Get("signature"), query.Get("timestamp"), query.Get("nonce")) {
+		cs.client.errorf("%s err: %v", cs.name, ErrCallbackSignature)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		// URL verification of the server configuration
+		echostr, err := cs.echo(query)
+		if err != nil {
+			cs.client.errorf("%s err: %v", cs.name, err)
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		w.Write(echostr)
+	case http.MethodPost:
+		reply, isJSON, err := cs.serve(r)
+		if errors.Is(err, ErrCallbackQueueFull) {
+			cs.client.errorf("%s err: %v", cs.name, err)
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+		if err != nil {
+			cs.client.errorf("%s err: %v", cs.name, err)
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		if isJSON {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		} else {
+			w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		}
+		w.Write(reply)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// echo returns the answer to the URL verification. WeCom sends echostr
+// encrypted and signed by msg_signature.
+func (cs *callbackServer) echo(query url.Values) ([]byte, error) {
+	echostr := query.Get("echostr")
+	if !cs.wecom {
+		return []byte(echostr), nil
+	}
+	if !cs.crypto.verify(query.Get("msg_signature"), query.Get("timestamp"), query.Get("nonce"), echostr) {
+		return nil, ErrCallbackSignature
+	}
+	return cs.crypto.decrypt(echostr)
+}
+
+// serve decodes and handles the message of r and returns the reply, in
+// the format of the message.
+func (cs *callbackServer) serve(r *http.Request) ([]byte, bool, error) {
+	body, err := readCallbackBody(r)
+	if err != nil {
+		return nil, false, err
+	}
+	isJSON := isJSONMessage(body)
+	raw, encrypted, err := cs.open(r.URL.Query(), body, isJSON)
+	if err != nil {
+		return nil, isJSON, err
+	}
+	msg, err := cs.decode(raw)
+	if err != nil {
+		return nil, isJSON, err
+	}
+	ctx := r.Context()
+	key := cs.dedupKey(raw, msg, isJSON)
+	if prev, dup := cs.claim(ctx, key); dup {
+		cs.client.infof("%s drops retried message %s", cs.name, callbackRoute(msg.MessageHeader().MsgType, msg.MessageHeader().Event))
+		return prev, isJSON, nil
+	}
+	if cs.pool != nil {
+		if !cs.pool.push(msg) {
+			// Let wechat retry later.
+			cs.release(key)
+			return nil, isJSON, ErrCallbackQueueFull
+		}
+		cs.finish(key, []byte(ReplySuccess))
+		return []byte(ReplySuccess), isJSON, nil
+	}
+	reply, err := cs.dispatch(ctx, msg)
+	if err != nil {
+		// Wechat retries if the reply is late, not if it is an error page,
+		// so the error is only logged.
+		cs.client.errorf("%s handle %s err: %v", cs.name, callbackRoute(msg.MessageHeader().MsgType, msg.MessageHeader().Event), err)
+		cs.finish(key, []byte(ReplySuccess))
+		return []byte(ReplySuccess), isJSON, nil
+	}
+	out, err := cs.seal(reply, encrypted, isJSON)
+	if err != nil {
+		cs.release(key)
+		return nil, isJSON, err
+	}
+	cs.finish(key, out)
+	return out, isJSON, nil
+}
+
+// open returns the plaintext message of the body, decrypted if wechat
+// sent it encrypted and the mode allows it.
+func (cs *callbackServer) open(query url.Values, body []byte, isJSON bool) ([]byte, bool, error) {
+	encrypted := query.Get("encrypt_type") == "aes" || cs.wecom
+	if !encrypted || cs.mode == CallbackModePlaintext && !cs.wecom {
+		if cs.mode == CallbackModeSafe {
+			return nil, false, errors.New("plaintext message in safe mode")
+		}
+		// In compatible mode, the plaintext fields are sent along.
+		return body, false, nil
+	}
+	envelope := new(callbackEnvelope)
+	var err error
+	if isJSON {
+		err = json.Unmarshal(body, envelope)
+	} else {
+		err = xml.Unmarshal(body, envelope)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if !cs.crypto.verify(query.Get("msg_signature"), query.Get("timestamp"), query.Get("nonce"), envelope.Encrypt) {
+		return nil, false, ErrCallbackSignature
+	}
+	raw, err := cs.crypto.decrypt(envelope.Encrypt)
+	if err != nil {
+		return nil, false, err
+	}
+	return raw, true, nil
+}
+
+// seal returns the body of reply, encrypted if the message was.
+func (cs *callbackServer) seal(reply interface{}, encrypted, isJSON bool) ([]byte, error) {
+	if reply == nil {
+		reply = ReplySuccess
+	}
+	out, err := marshalCallbackReply(reply, isJSON)
+	if err != nil {
+		return nil, err
+	}
+	if _, raw := reply.(RawReply); raw || !encrypted {
+		return out, nil
+	}
+	sealed, err := cs.crypto.encrypt(out)
+	if err != nil {
+		return nil, err
+	}
+	if isJSON {
+		return json.Marshal(sealed)
+	}
+	return xml.Marshal(sealed)
+}
+
+// dispatch calls the handler of msg.
+func (cs *callbackServer) dispatch(ctx context.Context, msg callbackMessage) (interface{}, error) {
+	header := msg.MessageHeader()
+	handler, ok := cs.handlers[callbackRoute(header.MsgType, header.Event)]
+	if !ok {
+		handler = cs.fallback
+	}
+	if handler == nil {
+		return nil, nil
+	}
+	return handler(ctx, msg)
+}
+
+// callbackRoute returns the route of a message type, or of an event if
+// msgType is MsgTypeEvent. Events are matched case-insensitive.
+func callbackRoute(msgType, event string) string {
+	if msgType != MsgTypeEvent {
+		return msgType
+	}
+	return MsgTypeEvent + "." + strings.ToLower(event)
+}
+
+// -- retries --
+
+// DefaultCallbackDedupWindow is how long callback servers remember
+// messages to drop the retries of wechat, see SetDedupWindow.
+const DefaultCallbackDedupWindow = 30 * time.Second
+
+// ErrCallbackQueueFull is raised if an asynchronous callback server can't
+// take more messages, which is answered with 503, so wechat retries later.
+var ErrCallbackQueueFull = errors.New("wechat: callback queue is full")
+
+// dedupKey returns the cache key of msg: messages are identified by MsgId,
+// events by FromUserName, CreateTime and a digest of the event, as events
+// of the same user may share a second. It is empty if deduplication is
+// disabled.
+func (cs *callbackServer) dedupKey(raw []byte, msg callbackMessage, isJSON bool) string {
+	if cs.dedupWindow <= 0 {
+		return ""
+	}
+	header := msg.MessageHeader()
+	var id struct {
+		MsgID json.Number `xml:"MsgId" json:"MsgId"`
+	}
+	if isJSON {
+		json.Unmarshal(raw, &id)
+	} else {
+		xml.Unmarshal(raw, &id)
+	}
+	key := fmt.Sprintf("%scallback.%s.%s.", cachekeyPrefix, cs.name, header.ToUserName)
+	if id.MsgID != "" {
+		key += "msg." + id.MsgID.String()
+	} else {
+		key += fmt.Sprintf("event.%s.%d.%s", header.FromUserName, header.CreateTime, MD5Sum(string(raw)))
+	}
+	return MD5Sum(key)
+}
+
+// claim returns true and the reply of the first delivery if the message of
+// key was received before. Otherwise it marks the message as in flight
+// until finish or release is called. A retry of a message in flight in this
+// process waits for its reply up to callbackInflightWait, other retries are
+// answered with ReplySuccess. With a Locker, the check and the mark are
+// atomic across the processes sharing the cache.
+func (cs *callbackServer) claim(ctx context.Context, key string) ([]byte, bool) {
+	if key == "" {
+		return nil, false
+	}
+	for {
+		cs.inflight.mu.Lock()
+		done, ok := cs.inflight.done[key]
+		if !ok {
+			cs.inflight.done[key] = make(chan struct{})
+		}
+		cs.inflight.mu.Unlock()
+		if !ok {
+			break
+		}
+		if !cs.inflight.wait(ctx, done) {
+			return []byte(ReplySuccess), true
+		}
+		// The first delivery is answered, or released to be handled again.
+		if reply, ok := cs.cached(ctx, key); ok {
+			return reply, true
+		}
+	}
+
+	cs.client.mu.RLock()
+	locker, lease := cs.client.locker, cs.client.lockLease
+	cs.client.mu.RUnlock()
+	if locker != nil {
+		token, err := locker.Lock(ctx, key+".lock", lease)
+		if err != nil {
+			cs.client.errorf("%s lock err: %v", cs.name, err)
+		} else {
+			defer func() {
+				uctx, cancel := context.WithTimeout(context.Background(), lease)
+				defer cancel()
+				if err := locker.Unlock(uctx, key+".lock", token); err != nil {
+					cs.client.errorf("%s unlock err: %v", cs.name, err)
+				}
+			}()
+		}
+	}
+	if reply, ok := cs.cached(ctx, key); ok {
+		// in flight or answered by another process
+		cs.inflight.drop(key)
+		return reply, true
+	}
+	cs.remember(ctx, key, []byte(ReplySuccess))
+	return nil, false
+}
+
+// finish replaces the in flight mark of the message of key with its reply.
+func (cs *callbackServer) finish(key string, reply []byte) {
+	if key == "" {
+		return
+	}
+	// The request may be canceled already, as wechat gave up waiting.
+	cs.remember(context.Background(), key, reply)
+	cs.inflight.drop(key)
+}
+
+// release drops the in flight mark of the message of key, so its retry is
+// handled.
+func (cs *callbackServer) release(key string) {
+	if key == "" {
+		return
+	}
+	if err := cs.client.cache.Delete(context.Background(), key); err != nil {
+		cs.client.errorf("%s cache delete err: %v", cs.name, err)
+	}
+	cs.inflight.drop(key)
+}
+
+// cached returns the cached reply of the message of key.
+func (cs *callbackServer) cached(ctx context.Context, key string) ([]byte, bool) {
+	value, err := cs.client.cache.Get(ctx, key)
+	if err != nil {
+		return nil, false
+	}
+	switch v := value.(type) {
+	case string:
+		return []byte(v), true
+	case []byte:
+		return v, true
+	}
+	return []byte(ReplySuccess), true
+}
+
+// remember caches the reply of the message of key.
+func (cs *callbackServer) remember(ctx context.Context, key string, reply []byte) {
+	if key == "" {
+		return
+	}
+	if err := cs.client.cache.Set(ctx, key, string(reply), cs.dedupWindow); err != nil {
+		cs.client.errorf("%s cache set err: %v", cs.name, err)
+	}
+}
+
+// callbackInflightWait is how long a retry waits for the reply of the first
+// delivery, less than the 5 seconds wechat waits for a reply.
+const callbackInflightWait = 4 * time.Second
+
+// callbackInflight tracks the messages handled by this process, so their
+// retries wait for the reply.
+type callbackInflight struct {
+	mu   sync.Mutex
+	done map[string]chan struct{} // closed when the message is answered
+}
+
+// wait returns true once done is closed, false if it takes longer than
+// callbackInflightWait or ctx is done.
+func (ci *callbackInflight) wait(ctx context.Context, done <-chan struct{}) bool {
+	timer := time.NewTimer(callbackInflightWait)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return false
+}
+
+// drop wakes up the retries waiting for the message of key.
+func (ci *callbackInflight) drop(key string) {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+	if done, ok := ci.done[key]; ok {
+		close(done)
+		delete(ci.done, key)
+	}
+}
+
+// setDedupWindow sets how long messages are remembered to drop the retries
+// of wechat, DefaultCallbackDedupWindow by default, 0 disables it. Retries
+// are answered with the reply to the first delivery. The client cache is
+// used, share it between instances to deduplicate across them.
+//
+// Wechat retries a message if it isn't answered within 5 seconds. Such a
+// retry waits for the reply of the handler, or is answered with
+// ReplySuccess, but isn't handled again.
+func (cs *callbackServer) setDedupWindow(window time.Duration) {
+	cs.dedupWindow = window
+}
+
+// setAsync makes the server answer ReplySuccess right away and handle the
+// messages with workers, at most queueSize messages wait for them and more
+// are answered with 503, see ErrCallbackQueueFull. Passive replies of the
+// handlers are dropped, and the handlers get a context without deadline.
+func (cs *callbackServer) setAsync(workers, queueSize int) {
+	if cs.pool != nil {
+		cs.pool.close()
+	}
+	cs.pool = newCallbackPool(workers, queueSize, func(msg callbackMessage) {
+		defer func() {
+			if r := recover(); r != nil {
+				cs.client.errorf("%s handler panic: %v", cs.name, r)
+			}
+		}()
+		reply, err := cs.dispatch(context.Background(), msg)
+		if err != nil {
+			cs.client.errorf("%s handle %s err: %v", cs.name, callbackRoute(msg.MessageHeader().MsgType, msg.MessageHeader().Event), err)
+			return
+		}
+		if reply != nil && reply != ReplySuccess && reply != ReplyEmpty {
+			cs.client.infof("%s drops the reply to %s, passive replies can't be sent asynchronously", cs.name, callbackRoute(msg.MessageHeader().MsgType, msg.MessageHeader().Event))
+		}
+	})
+}
+
+// close stops the workers once the queued messages are handled, see
+// setAsync.
+func (cs *callbackServer) close() {
+	if cs.pool != nil {
+		cs.pool.close()
+	}
+}
+
+// callbackPool is a bounded queue of messages handled by a fixed number of
+// workers.
+type callbackPool struct {
+	mu     sync.RWMutex
+	closed bool
+	queue  chan callbackMessage
+	wg     sync.WaitGroup
+}
+
+func newCallbackPool(workers, queueSize int, handle func(msg callbackMessage)) *callbackPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	p := &callbackPool{
+		queue: make(chan callbackMessage, queueSize),
+	}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer p.wg.Done()
+			for msg := range p.queue {
+				handle(msg)
+			}
+		}()
+	}
+	return p
+}
+
+// push queues msg, it returns false if the queue is full or closed.
+func (p *callbackPool) push(msg callbackMessage) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return false
+	}
+	select {
+	case p.queue <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// close waits until the queued messages are handled.
+func (p *callbackPool) close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
@@ -1,12 +1,10 @@
 package wechat
 
 import (
-	"bytes"
+	"bufio"
 	"context"
 	"crypto/tls"
 	"fmt"
-	"io"
-	"mime/multipart"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
@@ -31,11 +29,34 @@ const (
 	// DefaultGzipEnabled specifies if gzip compression is enabled by default.
 	DefaultGzipEnabled = false
 
+	// DefaultCheckErrCode specifies if Do methods return a non-zero errcode
+	// as an *APIError by default.
+	DefaultCheckErrCode = true
+
 	// DefaultCacheExpiration of access token
 	DefaultCacheExpiration = 7200 * time.Second
 
 	// DefaultCacheInterval cleanup cache
 	DefaultCacheInterval = 240 * time.Minute
+
+	// DefaultHealthcheckEnabled specifies if dead hosts of a host pool
+	// are probed periodically by default.
+	DefaultHealthcheckEnabled = true
+
+	// DefaultHealthcheckInterval is the interval between two probes of
+	// dead hosts.
+	DefaultHealthcheckInterval = 30 * time.Second
+
+	// DefaultHealthcheckTimeout specifies the time a probe may take.
+	DefaultHealthcheckTimeout = 1 * time.Second
+
+	// DefaultRefreshFraction is the fraction of expires_in after which
+	// the background refresher replaces a token.
+	DefaultRefreshFraction = 0.8
+
+	// DefaultLockLease is the lease of the Locker lock held while
+	// refreshing an access token.
+	DefaultLockLease = 30 * time.Second
 )
 
 var (
@@ -72,8 +93,30 @@ type Client struct {
 	decoder       Decoder // used to decode data sent from wechat
 	sendGetBodyAs string  // override for when sending a GET with a body
 	gzipEnabled   bool    // gzip compression enabled or disabled (default)
+	checkErrCode  bool    // return non-zero errcodes from Do methods as *APIError
+
+	hostURLs        map[string]string    // overridden base urls by logical host
+	failoverEnabled bool                 // pool office account and mini program hosts with DefaultAPIHosts
+	hostPoolHosts   map[string][]string  // hosts of the pools by logical host, see SetHostPool
+	hostPools       map[string]*hostPool // failover pools by logical host
+
+	healthcheckEnabled  bool          // probe dead hosts of host pools
+	healthcheckInterval time.Duration // interval between probes
+	healthcheckTimeout  time.Duration // timeout of a probe
+
+	retrier Retrier // strategy for retries
 
-	cache Cache // Cache backend, used for saving access token etc.
+	bgctx          context.Context    // context of the background processes, canceled by Stop
+	stopBackground context.CancelFunc // cancels bgctx
+	wg             sync.WaitGroup     // background processes
+
+	refreshSources  map[string]IAccessToken // tokens refreshed in the background by cache key
+	refreshFraction float64                 // fraction of expires_in after which tokens are refreshed
+
+	cache       Cache         // Cache backend, used for saving access token etc.
+	locker      Locker        // optional lock held while refreshing tokens
+	lockLease   time.Duration // lease of the locker lock
+	tokenFlight flightGroup   // deduplicates concurrent token fetches
 }
 
 // NewClient creates a new short-lived Client that can be used in
@@ -85,6 +128,18 @@ func NewClient(options ...ClientOptionFunc) (*Client, error) {
 		decoder:       &DefaultDecoder{},
 		sendGetBodyAs: DefaultSendGetBodyAs,
 		gzipEnabled:   DefaultGzipEnabled,
+		checkErrCode:  DefaultCheckErrCode,
+		hostURLs:      make(map[string]string),
+		hostPoolHosts: make(map[string][]string),
+		hostPools:     make(map[string]*hostPool),
+
+		healthcheckEnabled:  DefaultHealthcheckEnabled,
+		healthcheckInterval: DefaultHealthcheckInterval,
+		healthcheckTimeout:  DefaultHealthcheckTimeout,
+		retrier:             NewStopRetrier(),
+		lockLease:           DefaultLockLease,
+		refreshSources:      make(map[string]IAccessToken),
+		refreshFraction:     DefaultRefreshFraction,
 	}
 	// Run the options on it
 	for _, option := range options {
@@ -105,9 +160,19 @@ func NewClient(options ...ClientOptionFunc) (*Client, error) {
 		}
 		c.cache = cache
 	}
-	c.mu.Lock()
-	c.running = true
-	c.mu.Unlock()
+	if c.failoverEnabled {
+		for _, host := range []string{OfficeAccountHost, MiniProgramHost} {
+			_, pooled := c.hostPoolHosts[host]
+			_, overridden := c.hostURLs[host]
+			if !pooled && !overridden {
+				c.hostPoolHosts[host] = DefaultAPIHosts
+			}
+		}
+	}
+	for host, hosts := range c.hostPoolHosts {
+		c.hostPools[host] = newHostPool(host, c.scheme, hosts)
+	}
+	c.Start()
 	return c, nil
 }
 
@@ -119,6 +184,44 @@ func SetCacheBackend(cache Cache) ClientOptionFunc {
 	}
 }
 
+// SetLocker sets a Locker that goes along with a shared cache backend.
+// BasicAccessToken then acquires it before fetching a new token and checks
+// the cache again once it holds the lock, so only one process refreshes a
+// token. Callback servers hold it while claiming a message, so a message
+// is handled by one process only. There is no locker by default.
+func SetLocker(locker Locker) ClientOptionFunc {
+	return func(c *Client) error {
+		c.locker = locker
+		return nil
+	}
+}
+
+// SetLockLease sets the lease of the lock acquired from the Locker, it is
+// DefaultLockLease by default.
+func SetLockLease(lease time.Duration) ClientOptionFunc {
+	return func(c *Client) error {
+		if lease <= 0 {
+			return fmt.Errorf("SetLockLease: invalid lease %v", lease)
+		}
+		c.lockLease = lease
+		return nil
+	}
+}
+
+// SetRefreshFraction sets the fraction of expires_in after which access
+// tokens registered with RegisterAccessToken are refreshed, e.g. 0.8 renews
+// a token valid for 7200s after about 5760s. It is DefaultRefreshFraction
+// by default.
+func SetRefreshFraction(fraction float64) ClientOptionFunc {
+	return func(c *Client) error {
+		if fraction <= 0 || fraction >= 1 {
+			return fmt.Errorf("SetRefreshFraction: fraction %v not in (0, 1)", fraction)
+		}
+		c.refreshFraction = fraction
+		return nil
+	}
+}
+
 // SetHTTPClient can be used to specify the http.Client to use when making
 // HTTP requests to wechat.
 func SetHTTPClient(httpClient *http.Client) ClientOptionFunc {
@@ -179,6 +282,116 @@ func SetScheme(scheme string) ClientOptionFunc {
 	}
 }
 
+// SetHostURL overrides the base url of a logical host like OfficeAccountHost
+// or MiniProgramHost, e.g. to point the client at an httptest server, an
+// egress proxy or the api2.weixin.qq.com backup. The base url must contain
+// a scheme and a host, a path prefix is kept. A real host such as
+// "api.weixin.qq.com" can be used as key as well.
+func SetHostURL(host, baseURL string) ClientOptionFunc {
+	return func(c *Client) error {
+		if host == "" {
+			return ErrNoBaseURI
+		}
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return errors.Wrap(err, "SetHostURL")
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("SetHostURL: invalid base url %q for host %s", baseURL, host)
+		}
+		c.hostURLs[host] = strings.TrimRight(baseURL, "/")
+		return nil
+	}
+}
+
+// SetHostURLs overrides the base urls of several logical hosts at once,
+// see SetHostURL.
+func SetHostURLs(urls map[string]string) ClientOptionFunc {
+	return func(c *Client) error {
+		for host, baseURL := range urls {
+			if err := SetHostURL(host, baseURL)(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// SetHostPool lets the client fail over between several hosts serving the
+// same logical host. Hosts are real hosts like BackupBaseHost or base urls,
+// in order of preference. A host is marked as dead on connection errors and
+// 5xx responses, the request is then sent to the next host that is alive.
+// Non-idempotent requests like POST are sent again on connection errors
+// only if the connection failed before the request was written, other
+// errors are left to the retrier. Dead hosts are probed periodically, see
+// SetHealthcheck.
+func SetHostPool(host string, hosts ...string) ClientOptionFunc {
+	return func(c *Client) error {
+		if host == "" {
+			return ErrNoBaseURI
+		}
+		if len(hosts) == 0 {
+			delete(c.hostPoolHosts, host)
+			return nil
+		}
+		c.hostPoolHosts[host] = hosts
+		return nil
+	}
+}
+
+// SetFailover enables failover between DefaultAPIHosts for office account
+// and mini program requests, unless these hosts are configured with
+// SetHostPool or SetHostURL. It is disabled by default.
+func SetFailover(enabled bool) ClientOptionFunc {
+	return func(c *Client) error {
+		c.failoverEnabled = enabled
+		return nil
+	}
+}
+
+// SetHealthcheck enables or disables the periodic probing of dead hosts
+// of host pools. It is enabled by default. If disabled, all hosts of a
+// pool are marked as alive again once none is left.
+func SetHealthcheck(enabled bool) ClientOptionFunc {
+	return func(c *Client) error {
+		c.healthcheckEnabled = enabled
+		return nil
+	}
+}
+
+// SetHealthcheckInterval sets the interval between two probes of dead
+// hosts. It is DefaultHealthcheckInterval by default.
+func SetHealthcheckInterval(interval time.Duration) ClientOptionFunc {
+	return func(c *Client) error {
+		if interval <= 0 {
+			return fmt.Errorf("SetHealthcheckInterval: invalid interval %v", interval)
+		}
+		c.healthcheckInterval = interval
+		return nil
+	}
+}
+
+// SetHealthcheckTimeout sets the timeout of a probe. It is
+// DefaultHealthcheckTimeout by default.
+func SetHealthcheckTimeout(timeout time.Duration) ClientOptionFunc {
+	return func(c *Client) error {
+		c.healthcheckTimeout = timeout
+		return nil
+	}
+}
+
+// SetRetrier specifies the retry strategy that handles errors during
+// HTTP request/response with wechat. By default, no retries are done.
+func SetRetrier(retrier Retrier) ClientOptionFunc {
+	return func(c *Client) error {
+		if retrier == nil {
+			retrier = NewStopRetrier()
+		}
+		c.retrier = retrier
+		return nil
+	}
+}
+
 // SetSendGetBodyAs specifies the HTTP method to use when sending a GET request
 // with a body. It is GET by default.
 func SetSendGetBodyAs(httpMethod string) ClientOptionFunc {
@@ -196,6 +409,17 @@ func SetGzip(enabled bool) ClientOptionFunc {
 	}
 }
 
+// SetCheckErrCode specifies if the Do methods of the builders return a
+// non-zero errcode as an *APIError (enabled by default). Disable it to get
+// the raw response struct and check its errcode yourself. The response is
+// returned along with the *APIError either way.
+func SetCheckErrCode(enabled bool) ClientOptionFunc {
+	return func(c *Client) error {
+		c.checkErrCode = enabled
+		return nil
+	}
+}
+
 // SetDecoder sets the Decoder to use when decoding data from Wechat.
 // DefaultDecoder is used by default.
 func SetDecoder(decoder Decoder) ClientOptionFunc {
@@ -217,38 +441,111 @@ func (c *Client) IsRunning() bool {
 	return c.running
 }
 
-// Start starts the background processes like periodic health checks.
+// Start starts the background processes like periodic health checks and
+// the refresh of access tokens registered with RegisterAccessToken.
 // You don't need to run Start when creating a client with NewClient;
 // the background processes are run by default.
 //
 // If the background processes are already running, this is a no-op.
 func (c *Client) Start() {
-	c.mu.RLock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.running {
-		c.mu.RUnlock()
 		return
 	}
-	c.mu.RUnlock()
-
-	c.mu.Lock()
+	c.bgctx, c.stopBackground = context.WithCancel(context.Background())
+	if c.healthcheckEnabled && len(c.hostPools) > 0 {
+		c.wg.Add(1)
+		go func(stop <-chan struct{}) {
+			defer c.wg.Done()
+			c.healthchecker(stop)
+		}(c.bgctx.Done())
+	}
+	for _, iat := range c.refreshSources {
+		c.startRefresher(c.bgctx, iat)
+	}
 	c.running = true
-	c.mu.Unlock()
 }
 
-// Stop stops the background processes that the client is running,
+// Stop stops the background processes that the client is running and
+// waits for them to finish.
 //
 // If the background processes are not running, this is a no-op.
 func (c *Client) Stop() {
-	c.mu.RLock()
+	c.mu.Lock()
 	if !c.running {
-		c.mu.RUnlock()
+		c.mu.Unlock()
 		return
 	}
-	c.mu.RUnlock()
-
-	c.mu.Lock()
+	c.stopBackground()
 	c.running = false
 	c.mu.Unlock()
+
+	c.wg.Wait()
+}
+
+// BaseURL returns the base url, without trailing slash, that requests to
+// the given logical host are sent to.
+func (c *Client) BaseURL(host string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.baseURL(host)
+}
+
+// baseURL resolves a logical or real host to a base url.
+// The caller must hold c.mu.
+func (c *Client) baseURL(host string) string {
+	if p, ok := c.hostPools[host]; ok {
+		for _, h := range p.hosts {
+			if !h.IsDead() {
+				return h.url
+			}
+		}
+	}
+	if u, ok := c.hostURLs[host]; ok {
+		return u
+	}
+	if h, ok := defaultHosts[host]; ok {
+		return c.scheme + "://" + h
+	}
+	return c.scheme + "://" + host
+}
+
+// requestURL builds the full url of a request. If the host is served by a
+// host pool, the chosen host is returned as well.
+// The caller must hold c.mu.
+func (c *Client) requestURL(opt PerformRequestOptions) (string, *hostConn, error) {
+	if opt.BaseURI == "" {
+		return "", nil, ErrNoBaseURI
+	}
+	if opt.Endpoint == "" {
+		return "", nil, ErrNoEndpoint
+	}
+	var (
+		base string
+		conn *hostConn
+	)
+	if p, ok := c.hostPools[opt.BaseURI]; ok {
+		var (
+			switched bool
+			err      error
+		)
+		conn, switched, err = p.next(!c.healthcheckEnabled || !c.running)
+		if err != nil {
+			return "", nil, err
+		}
+		if switched {
+			c.infof("wechat: %s requests are sent to %s", p.name, conn.url)
+		}
+		base = conn.url
+	} else {
+		base = c.baseURL(opt.BaseURI)
+	}
+	pathWithParams := base + "/" + strings.TrimLeft(opt.Endpoint, "/")
+	if len(opt.Params) > 0 {
+		pathWithParams += "?" + opt.Params.Encode()
+	}
+	return pathWithParams, conn, nil
 }
 
 // errorf logs to the error log.
@@ -283,9 +580,9 @@ func (c *Client) dumpRequest(r *http.Request) {
 }
 
 // dumpResponse dumps the given HTTP response to the trace log.
-func (c *Client) dumpResponse(resp *http.Response) {
+func (c *Client) dumpResponse(resp *http.Response, body bool) {
 	if c.tracelog != nil {
-		out, err := httputil.DumpResponse(resp, true)
+		out, err := httputil.DumpResponse(resp, body)
 		if err == nil {
 			c.tracef("%s\n", string(out))
 		}
@@ -308,95 +605,176 @@ type PerformRequestOptions struct {
 	MaxResponseSize int64
 	BaseURI         string
 	Endpoint        string
+	Retrier         Retrier // overrides the client's retrier
+	// Multipart is the body of PerformFormRequest. If it is nil, a body with
+	// the single file FormValue is sent.
+	Multipart *Multipart
+	// Stream hands the body of a successful response over to
+	// Response.Stream instead of reading it into Response.Body, e.g. for
+	// media downloads. JSON bodies, i.e. errcodes, are still read into Body.
+	// The caller must close Response.Stream.
+	Stream bool
 }
 
 // PerformRequest does a HTTP request to wechat.
 func (c *Client) PerformRequest(ctx context.Context, opt PerformRequestOptions) (*Response, error) {
+	c.mu.RLock()
+	gzipEnabled := c.gzipEnabled
+	c.mu.RUnlock()
+
+	return c.performRequest(ctx, opt, func(req *Request) error {
+		if opt.Body == nil {
+			return nil
+		}
+		if err := req.SetBody(opt.Body, gzipEnabled); err != nil {
+			c.errorf("wechat: couldn't set body %+v for request: %v", opt.Body, err)
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return nil
+	})
+}
+
+// PerformFormRequest does a multipart/form-data HTTP request to wechat with
+// the body opt.Multipart, which is streamed.
+func (c *Client) PerformFormRequest(ctx context.Context, opt PerformRequestOptions) (*Response, error) {
+	body := opt.Multipart
+	if body == nil {
+		body = NewMultipart().AddFileBytes(opt.FormFieldName, opt.FormFileName, "", opt.FormValue)
+	}
+	if err := body.Validate(); err != nil {
+		return nil, errors.Wrap(err, "PerformFormRequest")
+	}
+	contentLength := body.ContentLength()
+
+	return c.performRequest(ctx, opt, func(req *Request) error {
+		rc, err := body.open()
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", body.ContentType())
+		req.Body = rc
+		if contentLength >= 0 {
+			req.ContentLength = contentLength
+		}
+		return nil
+	})
+}
+
+// performRequest sends the request described by opt, setBody is called
+// for every attempt to attach a fresh body. The request is sent to the
+// next host of a host pool on connection errors and 5xx responses, and
+// retried as long as the retrier permits.
+func (c *Client) performRequest(ctx context.Context, opt PerformRequestOptions, setBody func(*Request) error) (*Response, error) {
 	start := time.Now().UTC()
 
-	c.mu.Lock()
+	c.mu.RLock()
 	sendGetBodyAs := c.sendGetBodyAs
-	gzipEnabled := c.gzipEnabled
-	pathWithParams := fmt.Sprintf("%s://%s/%s", c.scheme, opt.BaseURI, opt.Endpoint)
-	if len(opt.Params) > 0 {
-		pathWithParams += "?" + opt.Params.Encode()
+	retrier := c.retrier
+	c.mu.RUnlock()
+	if opt.Retrier != nil {
+		retrier = opt.Retrier
 	}
-	c.mu.Unlock()
-
-	var (
-		err  error
-		req  *Request
-		resp *Response
-	)
 
 	// Change method if sendGetBodyAs is specified.
 	if opt.Method == "GET" && opt.Body != nil && sendGetBodyAs != "GET" {
 		opt.Method = sendGetBodyAs
 	}
 
-	req, err = NewRequest(opt.Method, pathWithParams, nil)
-	if err != nil {
-		c.errorf("wechat: cannot create request for %s %s: %v", strings.ToUpper(opt.Method), pathWithParams, err)
-		return nil, err
-	}
-
-	if opt.ContentType != "" {
-		req.Header.Set("Content-Type", opt.ContentType)
-	}
+	var (
+		err     error
+		req     *Request
+		resp    *Response
+		res     *http.Response
+		retried int
+	)
 
-	if len(opt.Headers) > 0 {
-		for key, value := range opt.Headers {
-			for _, v := range value {
-				req.Header.Add(key, v)
-			}
+	for {
+		var (
+			pathWithParams string
+			conn           *hostConn
+			cause          error
+		)
+		// Drop the response of the previous attempt, so it isn't returned
+		// along with an error of this one.
+		resp, res = nil, nil
+		c.mu.RLock()
+		pathWithParams, conn, err = c.requestURL(opt)
+		c.mu.RUnlock()
+		if err != nil {
+			c.errorf("wechat: cannot resolve %s for %s: %v", opt.BaseURI, opt.Endpoint, err)
+			return nil, errors.Wrap(err, "PerformRequest")
 		}
-	}
 
-	// Set body
-	if opt.Body != nil {
-		err = req.SetBody(opt.Body, gzipEnabled)
+		req, err = NewRequest(opt.Method, pathWithParams, nil)
 		if err != nil {
-			c.errorf("wechat: couldn't set body %+v for request: %v", opt.Body, err)
+			c.errorf("wechat: cannot create request for %s %s: %v", strings.ToUpper(opt.Method), pathWithParams, err)
 			return nil, err
 		}
-		req.Header.Set("Content-Type", "application/json")
-	}
-
-	// Tracing
-	c.dumpRequest((*http.Request)(req))
-
-	// Get response
-	res, err := c.httpClient.Do((*http.Request)(req).WithContext(ctx))
 
-	if err != nil {
-		c.errorf("wechat: couldn't do request body %+v for request: %v", opt.Body, err)
-		return nil, err
-	}
+		if opt.ContentType != "" {
+			req.Header.Set("Content-Type", opt.ContentType)
+		}
 
-	if IsContextErr(err) {
-		c.errorf("PerformRequest.IsContextErr err %v", err)
-		return nil, err
-	}
+		if len(opt.Headers) > 0 {
+			for key, value := range opt.Headers {
+				for _, v := range value {
+					req.Header.Add(key, v)
+				}
+			}
+		}
 
-	if res.Body != nil {
-		defer res.Body.Close()
-	}
+		// Set body
+		if err := setBody(req); err != nil {
+			return nil, err
+		}
 
-	// Tracing
-	c.dumpResponse(res)
+		// Tracing
+		c.dumpRequest((*http.Request)(req))
 
-	// Check for errors
-	if err := checkResponse((*http.Request)(req), res, opt.IgnoreErrors...); err != nil {
-		// We still try to return a response.
-		resp, err1 := c.newResponse(res, opt.MaxResponseSize)
-		c.tracef("PerformRequest.newResponse try again err %v", err1)
-		return resp, err1
-	}
+		// Get response
+		res, err = c.httpClient.Do((*http.Request)(req).WithContext(ctx))
+		if ctx.Err() != nil || IsContextErr(err) {
+			if err == nil {
+				res.Body.Close()
+				err = ctx.Err()
+			}
+			c.errorf("PerformRequest.IsContextErr err %v", err)
+			return nil, err
+		}
+		if err != nil {
+			if canFailover(opt.Method, err) && c.failover(conn, err) {
+				continue
+			}
+			cause = err
+		} else {
+			if res.StatusCode >= http.StatusInternalServerError && c.failover(conn, res.Status) {
+				res.Body.Close()
+				continue
+			}
+			// err is an *Error if the status isn't 2xx, resp is still set then.
+			resp, err = c.readResponse(req, res, opt)
+			if resp == nil {
+				return nil, err
+			}
+			if cause = retryCause(opt, res, resp); cause == nil {
+				break
+			}
+		}
 
-	resp, err = c.newResponse(res, opt.MaxResponseSize)
-	if err != nil {
-		c.tracef("PerformRequest.newResponse err %v", err)
-		return nil, err
+		retried++
+		goahead, rerr := c.retry(ctx, retrier, retried, req, res, cause)
+		if rerr != nil {
+			c.errorf("wechat: retry of %s %s aborted: %v", strings.ToUpper(opt.Method), req.URL, rerr)
+			return nil, rerr
+		}
+		if !goahead {
+			if err != nil && resp == nil {
+				c.errorf("wechat: couldn't do request body %+v for request: %v", opt.Body, err)
+				return nil, err
+			}
+			break
+		}
 	}
 
 	duration := time.Now().UTC().Sub(start)
@@ -406,101 +784,51 @@ func (c *Client) PerformRequest(ctx context.Context, opt PerformRequestOptions)
 		resp.StatusCode,
 		float64(int64(duration/time.Millisecond))/1000)
 
-	return resp, nil
+	return resp, err
 }
 
-// PerformFormRequest does a HTTP request to wechat.
-func (c *Client) PerformFormRequest(ctx context.Context, opt PerformRequestOptions) (*Response, error) {
-	start := time.Now().UTC()
-
-	c.mu.Lock()
-	sendGetBodyAs := c.sendGetBodyAs
-	pathWithParams := fmt.Sprintf("%s://%s/%s", c.scheme, opt.BaseURI, opt.Endpoint)
-	if len(opt.Params) > 0 {
-		pathWithParams += "?" + opt.Params.Encode()
-	}
-	c.mu.Unlock()
-
-	// Change method if sendGetBodyAs is specified.
-	if opt.Method == "GET" && opt.Body != nil && sendGetBodyAs != "GET" {
-		opt.Method = sendGetBodyAs
-	}
-
-	bodyBuf := &bytes.Buffer{}
-	bodyWriter := multipart.NewWriter(bodyBuf)
-	fileWriter, err := bodyWriter.CreateFormFile(opt.FormFieldName, opt.FormFileName)
-	if err != nil {
-		return nil, errors.Wrap(err, "CreateFormFile")
-	}
-	if _, err := io.Copy(fileWriter, bytes.NewReader(opt.FormValue)); err != nil {
-		return nil, errors.Wrap(err, "io.Copy")
-	}
-	contentType := bodyWriter.FormDataContentType()
-	bodyWriter.Close()
-
-	var (
-		req  *Request
-		resp *Response
-	)
-
-	req, err = NewRequest(opt.Method, pathWithParams, bodyBuf)
-	if err != nil {
-		c.errorf("wechat: cannot create request for %s %s: %v", strings.ToUpper(opt.Method), pathWithParams, err)
-		return nil, err
-	}
-
-	if len(opt.Headers) > 0 {
-		for key, value := range opt.Headers {
-			for _, v := range value {
-				req.Header.Add(key, v)
-			}
+// readResponse reads the response of an attempt and closes its body. If
+// the status isn't 2xx, it returns the response along with an *Error.
+func (c *Client) readResponse(req *Request, res *http.Response, opt PerformRequestOptions) (*Response, error) {
+	if opt.Stream && res.StatusCode >= 200 && res.StatusCode <= 299 {
+		body := bufio.NewReader(res.Body)
+		stream := &streamBody{Reader: body, Closer: res.Body}
+		if !isJSONBody(res.Header.Get("Content-Type"), body) {
+			c.dumpResponse(res, false)
+			return &Response{
+				StatusCode: res.StatusCode,
+				Header:     res.Header,
+				Stream:     stream,
+			}, nil
 		}
+		res.Body = stream
 	}
-	req.Header.Set("Content-Type", contentType)
+	defer res.Body.Close()
 
 	// Tracing
-	c.dumpRequest((*http.Request)(req))
-
-	// Get response
-	res, err := c.httpClient.Do((*http.Request)(req).WithContext(ctx))
+	c.dumpResponse(res, true)
 
+	resp, err := c.newResponse(res, opt.MaxResponseSize)
 	if err != nil {
-		c.errorf("wechat: couldn't do request body %+v for request: %v", opt.Body, err)
-		return nil, err
-	}
-
-	if IsContextErr(err) {
-		c.errorf("PerformRequest.IsContextErr err %v", err)
+		c.tracef("PerformRequest.newResponse err %v", err)
 		return nil, err
 	}
-
-	if res.Body != nil {
-		defer res.Body.Close()
+	// Check for errors, we still return the response.
+	if err := checkResponse((*http.Request)(req), res, resp.Body, opt.IgnoreErrors...); err != nil {
+		return resp, err
 	}
+	return resp, nil
+}
 
-	// // Tracing
-	c.dumpResponse(res)
-
-	// // Check for errors
-	if err := checkResponse(nil, res, opt.IgnoreErrors...); err != nil {
-		// We still try to return a response.
-		resp, err1 := c.newResponse(res, opt.MaxResponseSize)
-		c.tracef("PerformRequest.newResponse try again err %v", err1)
-		return resp, err1
+// retryCause returns why a response should be retried: an *Error for 5xx
+// or an *APIError if the body carries a non-zero errcode.
+func retryCause(opt PerformRequestOptions, res *http.Response, resp *Response) error {
+	if res.StatusCode >= http.StatusInternalServerError {
+		return &Error{Status: res.StatusCode}
 	}
-
-	resp, err = c.newResponse(res, opt.MaxResponseSize)
-	if err != nil {
-		c.tracef("PerformRequest.newResponse err %v", err)
-		return nil, err
+	ce := peekCommonError(resp.Body)
+	if ce == nil {
+		return nil
 	}
-
-	duration := time.Now().UTC().Sub(start)
-	c.infof("%s %s [status:%d, request:%.3fs]",
-		strings.ToUpper(opt.Method),
-		req.URL,
-		resp.StatusCode,
-		float64(int64(duration/time.Millisecond))/1000)
-
-	return resp, nil
+	return NewAPIError(opt.Endpoint, *ce)
 }
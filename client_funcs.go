@@ -12,6 +12,11 @@ func (c *Client) BasicMessage(accessToken IAccessToken, message IBasicMessage) *
 	return NewBasicMessage(c, accessToken, message)
 }
 
+// Ticket Ticket
+func (c *Client) Ticket(baseURI string, accessToken IAccessToken, ticketType string) *Ticket {
+	return NewTicket(c, baseURI, accessToken, ticketType)
+}
+
 // -- OfficeAccount API --
 
 // MiniProgramAccessToken Miniprogram Auth
@@ -19,6 +24,206 @@ func (c *Client) OfficeAccountAccessToken() *OfficeAccountAccessToken {
 	return NewOfficeAccountAccessToken(c)
 }
 
+// OfficeAccountStableAccessToken OfficeAccountStableAccessToken
+func (c *Client) OfficeAccountStableAccessToken() *StableAccessToken {
+	return NewStableAccessToken(c, OfficeAccountHost)
+}
+
+// OfficeAccountMediaGet OfficeAccountMediaGet
+func (c *Client) OfficeAccountMediaGet() *MediaGet {
+	return NewMediaGet(c, OfficeAccountHost)
+}
+
+// OfficeAccountMediaUpload OfficeAccountMediaUpload
+func (c *Client) OfficeAccountMediaUpload() *MediaUpload {
+	return NewMediaUpload(c, OfficeAccountHost)
+}
+
+// OfficeAccountMaterialAdd OfficeAccountMaterialAdd
+func (c *Client) OfficeAccountMaterialAdd() *OfficeAccountMaterialAdd {
+	return NewOfficeAccountMaterialAdd(c)
+}
+
+// OfficeAccountJSAPITicket OfficeAccountJSAPITicket
+func (c *Client) OfficeAccountJSAPITicket(accessToken IAccessToken) *Ticket {
+	return NewTicket(c, OfficeAccountHost, accessToken, TicketTypeJSAPI)
+}
+
+// OfficeAccountWxCardTicket OfficeAccountWxCardTicket
+func (c *Client) OfficeAccountWxCardTicket(accessToken IAccessToken) *Ticket {
+	return NewTicket(c, OfficeAccountHost, accessToken, TicketTypeWxCard)
+}
+
+// OfficeAccountMenuCreate OfficeAccountMenuCreate
+func (c *Client) OfficeAccountMenuCreate() *OfficeAccountMenuCreate {
+	return NewOfficeAccountMenuCreate(c)
+}
+
+// OfficeAccountMenuGetCurrent OfficeAccountMenuGetCurrent
+func (c *Client) OfficeAccountMenuGetCurrent() *OfficeAccountMenuGetCurrent {
+	return NewOfficeAccountMenuGetCurrent(c)
+}
+
+// OfficeAccountMenuDelete OfficeAccountMenuDelete
+func (c *Client) OfficeAccountMenuDelete() *OfficeAccountMenuDelete {
+	return NewOfficeAccountMenuDelete(c)
+}
+
+// OfficeAccountMenuAddConditional OfficeAccountMenuAddConditional
+func (c *Client) OfficeAccountMenuAddConditional() *OfficeAccountMenuAddConditional {
+	return NewOfficeAccountMenuAddConditional(c)
+}
+
+// OfficeAccountMenuDelConditional OfficeAccountMenuDelConditional
+func (c *Client) OfficeAccountMenuDelConditional() *OfficeAccountMenuDelConditional {
+	return NewOfficeAccountMenuDelConditional(c)
+}
+
+// OfficeAccountMenuTryMatch OfficeAccountMenuTryMatch
+func (c *Client) OfficeAccountMenuTryMatch() *OfficeAccountMenuTryMatch {
+	return NewOfficeAccountMenuTryMatch(c)
+}
+
+// OfficeAccountUserGet OfficeAccountUserGet
+func (c *Client) OfficeAccountUserGet() *OfficeAccountUserGet {
+	return NewOfficeAccountUserGet(c)
+}
+
+// OfficeAccountUserInfo OfficeAccountUserInfo
+func (c *Client) OfficeAccountUserInfo() *OfficeAccountUserInfo {
+	return NewOfficeAccountUserInfo(c)
+}
+
+// OfficeAccountUserBatchGet OfficeAccountUserBatchGet
+func (c *Client) OfficeAccountUserBatchGet() *OfficeAccountUserBatchGet {
+	return NewOfficeAccountUserBatchGet(c)
+}
+
+// OfficeAccountUserRemark OfficeAccountUserRemark
+func (c *Client) OfficeAccountUserRemark() *OfficeAccountUserRemark {
+	return NewOfficeAccountUserRemark(c)
+}
+
+// OfficeAccountBlacklistGet OfficeAccountBlacklistGet
+func (c *Client) OfficeAccountBlacklistGet() *OfficeAccountBlacklistGet {
+	return NewOfficeAccountBlacklistGet(c)
+}
+
+// OfficeAccountBlacklistAdd OfficeAccountBlacklistAdd
+func (c *Client) OfficeAccountBlacklistAdd() *OfficeAccountBlacklistUpdate {
+	return NewOfficeAccountBlacklistUpdate(c, OfficeAccountBlacklistAddEndpoint)
+}
+
+// OfficeAccountBlacklistRemove OfficeAccountBlacklistRemove
+func (c *Client) OfficeAccountBlacklistRemove() *OfficeAccountBlacklistUpdate {
+	return NewOfficeAccountBlacklistUpdate(c, OfficeAccountBlacklistRemoveEndpoint)
+}
+
+// OfficeAccountTagCreate OfficeAccountTagCreate
+func (c *Client) OfficeAccountTagCreate() *OfficeAccountTagCreate {
+	return NewOfficeAccountTagCreate(c)
+}
+
+// OfficeAccountTagGet OfficeAccountTagGet
+func (c *Client) OfficeAccountTagGet() *OfficeAccountTagGet {
+	return NewOfficeAccountTagGet(c)
+}
+
+// OfficeAccountTagUpdate OfficeAccountTagUpdate
+func (c *Client) OfficeAccountTagUpdate() *OfficeAccountTagUpdate {
+	return NewOfficeAccountTagUpdate(c)
+}
+
+// OfficeAccountTagDelete OfficeAccountTagDelete
+func (c *Client) OfficeAccountTagDelete() *OfficeAccountTagDelete {
+	return NewOfficeAccountTagDelete(c)
+}
+
+// OfficeAccountTagBatchTagging OfficeAccountTagBatchTagging
+func (c *Client) OfficeAccountTagBatchTagging() *OfficeAccountTagBatch {
+	return NewOfficeAccountTagBatch(c, OfficeAccountTagBatchTaggingEndpoint)
+}
+
+// OfficeAccountTagBatchUntagging OfficeAccountTagBatchUntagging
+func (c *Client) OfficeAccountTagBatchUntagging() *OfficeAccountTagBatch {
+	return NewOfficeAccountTagBatch(c, OfficeAccountTagBatchUntaggingEndpoint)
+}
+
+// OfficeAccountTagUserGet OfficeAccountTagUserGet
+func (c *Client) OfficeAccountTagUserGet() *OfficeAccountTagUserGet {
+	return NewOfficeAccountTagUserGet(c)
+}
+
+// OfficeAccountTagIDList OfficeAccountTagIDList
+func (c *Client) OfficeAccountTagIDList() *OfficeAccountTagIDList {
+	return NewOfficeAccountTagIDList(c)
+}
+
+// OfficeAccountTemplateAdd OfficeAccountTemplateAdd
+func (c *Client) OfficeAccountTemplateAdd() *OfficeAccountTemplateAdd {
+	return NewOfficeAccountTemplateAdd(c)
+}
+
+// OfficeAccountTemplateGetAll OfficeAccountTemplateGetAll
+func (c *Client) OfficeAccountTemplateGetAll() *OfficeAccountTemplateGetAll {
+	return NewOfficeAccountTemplateGetAll(c)
+}
+
+// OfficeAccountTemplateDelete OfficeAccountTemplateDelete
+func (c *Client) OfficeAccountTemplateDelete() *OfficeAccountTemplateDelete {
+	return NewOfficeAccountTemplateDelete(c)
+}
+
+// OfficeAccountIndustryGet OfficeAccountIndustryGet
+func (c *Client) OfficeAccountIndustryGet() *OfficeAccountIndustryGet {
+	return NewOfficeAccountIndustryGet(c)
+}
+
+// OfficeAccountIndustrySet OfficeAccountIndustrySet
+func (c *Client) OfficeAccountIndustrySet() *OfficeAccountIndustrySet {
+	return NewOfficeAccountIndustrySet(c)
+}
+
+// OfficeAccountServer OfficeAccountServer
+func (c *Client) OfficeAccountServer() *OfficeAccountServer {
+	return NewOfficeAccountServer(c)
+}
+
+// OfficeAccountOAuthURL OfficeAccountOAuthURL
+func (c *Client) OfficeAccountOAuthURL() *OfficeAccountOAuthURL {
+	return NewOfficeAccountOAuthURL(c)
+}
+
+// OfficeAccountOAuthAccessToken OfficeAccountOAuthAccessToken
+func (c *Client) OfficeAccountOAuthAccessToken() *OfficeAccountOAuthAccessToken {
+	return NewOfficeAccountOAuthAccessToken(c)
+}
+
+// OfficeAccountOAuthRefreshToken OfficeAccountOAuthRefreshToken
+func (c *Client) OfficeAccountOAuthRefreshToken() *OfficeAccountOAuthRefreshToken {
+	return NewOfficeAccountOAuthRefreshToken(c)
+}
+
+// OfficeAccountOAuthUserInfo OfficeAccountOAuthUserInfo
+func (c *Client) OfficeAccountOAuthUserInfo() *OfficeAccountOAuthUserInfo {
+	return NewOfficeAccountOAuthUserInfo(c)
+}
+
+// OfficeAccountOAuthAuth OfficeAccountOAuthAuth
+func (c *Client) OfficeAccountOAuthAuth() *OfficeAccountOAuthAuth {
+	return NewOfficeAccountOAuthAuth(c)
+}
+
+// OfficeAccountOAuthTokenStore OfficeAccountOAuthTokenStore
+func (c *Client) OfficeAccountOAuthTokenStore(appid string) *OfficeAccountOAuthTokenStore {
+	return NewOfficeAccountOAuthTokenStore(c, appid)
+}
+
+// OfficeAccountOAuthHandler OfficeAccountOAuthHandler
+func (c *Client) OfficeAccountOAuthHandler() *OfficeAccountOAuthHandler {
+	return NewOfficeAccountOAuthHandler(c)
+}
+
 // -- Miniprogram API --
 
 // MiniProgramAuth Miniprogram Auth
@@ -31,6 +236,11 @@ func (c *Client) MiniProgramAccessToken() *MiniProgramAccessToken {
 	return NewMiniProgramAccessToken(c)
 }
 
+// MiniProgramStableAccessToken MiniProgramStableAccessToken
+func (c *Client) MiniProgramStableAccessToken() *StableAccessToken {
+	return NewStableAccessToken(c, MiniProgramHost)
+}
+
 // MiniProgramPaid Miniprogram Auth
 func (c *Client) MiniProgramPaid() *MiniProgramPaid {
 	return NewMiniProgramPaid(c)
@@ -61,6 +271,11 @@ func (c *Client) MiniProgramAppCodeCreate() *MiniProgramAppCodeCreate {
 	return NewMiniProgramAppCodeCreate(c)
 }
 
+// MiniProgramServer MiniProgramServer
+func (c *Client) MiniProgramServer() *MiniProgramServer {
+	return NewMiniProgramServer(c)
+}
+
 // MiniProgramSecImg MiniProgramSecImg
 func (c *Client) MiniProgramSecImg() *MiniProgramSecImg {
 	return NewMiniProgramSecImg(c)
@@ -77,3 +292,28 @@ func (c *Client) MiniProgramSecMsg() *MiniProgramSecMsg {
 func (c *Client) WorkAccessToken() *WorkAccessToken {
 	return NewWorkAccessToken(c)
 }
+
+// WorkMediaGet WorkMediaGet
+func (c *Client) WorkMediaGet() *MediaGet {
+	return NewMediaGet(c, WorkHost)
+}
+
+// WorkMediaUpload WorkMediaUpload
+func (c *Client) WorkMediaUpload() *MediaUpload {
+	return NewMediaUpload(c, WorkHost)
+}
+
+// WorkJSAPITicket WorkJSAPITicket
+func (c *Client) WorkJSAPITicket(accessToken IAccessToken) *Ticket {
+	return NewTicket(c, WorkHost, accessToken, TicketTypeJSAPI)
+}
+
+// WorkAgentTicket WorkAgentTicket
+func (c *Client) WorkAgentTicket(accessToken IAccessToken) *Ticket {
+	return NewTicket(c, WorkHost, accessToken, TicketTypeAgentConfig)
+}
+
+// WorkServer WorkServer
+func (c *Client) WorkServer() *WorkServer {
+	return NewWorkServer(c)
+}
@@ -0,0 +1,304 @@
+package wechat
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestClient_SetHostURL(t *testing.T) {
+	var gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok","access_token":"token","expires_in":7200}`))
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name     string
+		host     string
+		baseURL  string
+		wantPath string
+	}{
+		{
+			name:     "logical host",
+			host:     OfficeAccountHost,
+			baseURL:  ts.URL,
+			wantPath: "/" + OfficeAccountAccessTokenEndpoint,
+		},
+		{
+			name:     "path prefix",
+			host:     OfficeAccountHost,
+			baseURL:  ts.URL + "/prefix/",
+			wantPath: "/prefix/" + OfficeAccountAccessTokenEndpoint,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(SetHostURL(tt.host, tt.baseURL))
+			if err != nil {
+				t.Log(err)
+				t.FailNow()
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			res, err := client.OfficeAccountAccessToken().SetAppID("appid").SetSecret("secret").Do(ctx)
+			if err != nil {
+				t.Log(err)
+				t.FailNow()
+			}
+			if res.AccessToken != "token" || gotPath != tt.wantPath {
+				t.Log(res, gotPath)
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestClient_BaseURL(t *testing.T) {
+	client, err := NewClient(SetHostURL(WorkHost, "http://127.0.0.1:8080"))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	if u := client.BaseURL(WorkHost); u != "http://127.0.0.1:8080" {
+		t.Log(u)
+		t.FailNow()
+	}
+	if u := client.BaseURL(MiniProgramHost); u != "https://"+MiniProgramBaseHost {
+		t.Log(u)
+		t.FailNow()
+	}
+	if _, err := NewClient(SetHostURL(WorkHost, "127.0.0.1:8080")); err == nil {
+		t.Log("base url without scheme should be rejected")
+		t.FailNow()
+	}
+}
+
+func TestClient_SetHostPool(t *testing.T) {
+	var primaryHits int
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryHits++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer primary.Close()
+	backup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"backup","expires_in":7200}`))
+	}))
+	defer backup.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	client, err := NewClient(
+		SetHostPool(OfficeAccountHost, down.URL, primary.URL, backup.URL),
+		SetHealthcheck(false),
+	)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	defer client.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		res, err := client.OfficeAccountAccessToken().SetAppID("appid").SetSecret("secret").Do(ctx)
+		if err != nil || res.AccessToken != "backup" {
+			t.Log(res, err)
+			t.FailNow()
+		}
+	}
+	if primaryHits != 1 {
+		t.Log("dead host should not be used again", primaryHits)
+		t.FailNow()
+	}
+	if u := client.BaseURL(OfficeAccountHost); u != backup.URL {
+		t.Log(u)
+		t.FailNow()
+	}
+}
+
+func TestClient_SetHostPoolBrokenConnection(t *testing.T) {
+	var primaryHits, backupHits int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&primaryHits, 1)
+		// the request arrived, but the connection breaks before the reply
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer primary.Close()
+	backup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&backupHits, 1)
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer backup.Close()
+
+	tests := []struct {
+		method     string
+		backupHits int32
+	}{
+		{http.MethodPost, 0},
+		{http.MethodGet, 1},
+	}
+	for _, tt := range tests {
+		atomic.StoreInt32(&primaryHits, 0)
+		atomic.StoreInt32(&backupHits, 0)
+		client, err := NewClient(
+			SetHostPool(OfficeAccountHost, primary.URL, backup.URL),
+			SetHealthcheck(false),
+		)
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		_, err = client.PerformRequest(ctx, PerformRequestOptions{
+			Method:   tt.method,
+			BaseURI:  OfficeAccountHost,
+			Endpoint: OATemplateMessageEndpoint,
+		})
+		cancel()
+		client.Stop()
+		if (err == nil) != (tt.backupHits == 1) || atomic.LoadInt32(&primaryHits) != 1 || atomic.LoadInt32(&backupHits) != tt.backupHits {
+			t.Log(tt.method, err, atomic.LoadInt32(&primaryHits), atomic.LoadInt32(&backupHits))
+			t.FailNow()
+		}
+	}
+}
+
+func TestClient_SetRetrier(t *testing.T) {
+	tests := []struct {
+		name  string
+		fails []func(w http.ResponseWriter)
+	}{
+		{
+			name: "system busy",
+			fails: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) { w.Write([]byte(`{"errcode":-1,"errmsg":"system error"}`)) },
+			},
+		},
+		{
+			name: "5xx",
+			fails: []func(w http.ResponseWriter){
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) },
+				func(w http.ResponseWriter) { w.WriteHeader(http.StatusInternalServerError) },
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits++
+				if hits <= len(tt.fails) {
+					tt.fails[hits-1](w)
+					return
+				}
+				w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+			}))
+			defer ts.Close()
+			client, err := NewClient(
+				SetHostURL(MiniProgramHost, ts.URL),
+				SetRetrier(NewBackoffRetrier(NewExponentialBackoff(time.Millisecond, 10*time.Millisecond, 3))),
+			)
+			if err != nil {
+				t.Log(err)
+				t.FailNow()
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			res, err := client.MiniProgramSecMsg().SetAccessToken("token").SetMessage("hello").Do(ctx)
+			if err != nil || res.ErrCode != 0 || hits != len(tt.fails)+1 {
+				t.Log(res, err, hits)
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestClient_RetryDropsStaleResponse(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		// then the connection breaks
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer ts.Close()
+	client, err := NewClient(
+		SetHostURL(MiniProgramHost, ts.URL),
+		SetRetrier(NewBackoffRetrier(NewExponentialBackoff(time.Millisecond, 10*time.Millisecond, 1))),
+	)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	res, err := client.PerformRequest(ctx, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Body:     `{"content":"hello"}`,
+		BaseURI:  MiniProgramHost,
+		Endpoint: MiniProgramSecMsgEndpoint,
+	})
+	var e *Error
+	if res != nil || err == nil || errors.As(err, &e) || atomic.LoadInt32(&hits) != 2 {
+		t.Log("the 5xx response of the first attempt should be dropped", res, err)
+		t.FailNow()
+	}
+}
+
+func TestBasicMessage_SendRefreshesToken(t *testing.T) {
+	var issued, sent int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + OfficeAccountAccessTokenEndpoint:
+			issued++
+			fmt.Fprintf(w, `{"access_token":"token%d","expires_in":7200}`, issued)
+		case "/" + OACustomMessageEndpoint:
+			sent++
+			if r.URL.Query().Get("access_token") != "token2" {
+				w.Write([]byte(`{"errcode":40001,"errmsg":"invalid credential"}`))
+				return
+			}
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+		}
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(OfficeAccountHost, ts.URL))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	iat := client.OfficeAccountAccessToken().SetAppID("appid").SetSecret("secret")
+	err = client.BasicMessage(iat, NewOACustomMessage(&OACustomMessage{
+		MsgBody: &OACustomMessageBody{
+			Touser:  "openid",
+			Msgtype: "text",
+			Text:    &OACustomText{Content: "hello"},
+		},
+	})).Send(ctx)
+	if err != nil || issued != 2 || sent != 2 {
+		t.Log(err, issued, sent)
+		t.FailNow()
+	}
+	if at := client.BasicAccessToken(iat).GetToken(ctx, false); at != "token2" {
+		t.Log("refreshed token should be cached", at)
+		t.FailNow()
+	}
+}
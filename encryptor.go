@@ -67,23 +67,36 @@ func AESEncryptMsg(random, rawXMLMsg []byte, appID string, aesKey []byte) (ciphe
 
 // DecryptMsg DecryptMsg
 func DecryptMsg(appID, encryptedMsg, aesKey string) (random, rawMsgXMLBytes []byte, err error) {
+	var receiveID string
+	random, rawMsgXMLBytes, receiveID, err = DecryptMsgReceiveID(encryptedMsg, aesKey)
+	if err != nil {
+		return random, rawMsgXMLBytes, errors.Wrap(err, "DecryptMsg")
+	}
+	if appID != receiveID {
+		err = fmt.Errorf("DecryptMsg appId caused failed: appid != getAppIDBytes")
+		return random, rawMsgXMLBytes, errors.Wrap(err, "DecryptMsg")
+	}
+	return
+}
+
+// DecryptMsgReceiveID is like DecryptMsg, but returns the trailing id of
+// the message instead of comparing it to the appid. For WeCom, it is the
+// CorpID or SuiteID, depending on the callback.
+func DecryptMsgReceiveID(encryptedMsg, aesKey string) (random, rawMsgXMLBytes []byte, receiveID string, err error) {
 	var encryptedMsgBytes, key, getAppIDBytes []byte
 	encryptedMsgBytes, err = base64.StdEncoding.DecodeString(encryptedMsg)
 	if err != nil {
-		return random, rawMsgXMLBytes, errors.Wrap(err, "DecryptMsg")
+		return random, rawMsgXMLBytes, receiveID, errors.Wrap(err, "DecryptMsgReceiveID")
 	}
 	key, err = aesKeyDecode(aesKey)
 	if err != nil {
-		return random, rawMsgXMLBytes, errors.Wrap(err, "DecryptMsg")
+		return random, rawMsgXMLBytes, receiveID, errors.Wrap(err, "DecryptMsgReceiveID")
 	}
 	random, rawMsgXMLBytes, getAppIDBytes, err = AESDecryptMsg(encryptedMsgBytes, key)
 	if err != nil {
-		return random, rawMsgXMLBytes, errors.Wrap(err, "DecryptMsg")
-	}
-	if appID != string(getAppIDBytes) {
-		err = fmt.Errorf("DecryptMsg appId caused failed: appid != getAppIDBytes")
-		return random, rawMsgXMLBytes, errors.Wrap(err, "DecryptMsg")
+		return random, rawMsgXMLBytes, receiveID, errors.Wrap(err, "DecryptMsgReceiveID")
 	}
+	receiveID = string(getAppIDBytes)
 	return
 }
 
@@ -1,7 +1,11 @@
 package wechat
 
+//go:generate go run errcode_gen.go
+
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -12,11 +16,169 @@ type CommonError struct {
 	ErrMsg  string `json:"errmsg"`
 }
 
+// ErrCategory groups errcodes that are handled alike.
+type ErrCategory string
+
+// categories of errcodes
+const (
+	ErrCategoryBusy         ErrCategory = "busy"
+	ErrCategoryToken        ErrCategory = "token"
+	ErrCategoryRateLimit    ErrCategory = "ratelimit"
+	ErrCategoryUserRefused  ErrCategory = "refused"
+	ErrCategoryContentRisky ErrCategory = "risky"
+)
+
+// ErrCodeInfo describes a documented errcode, see errcode.csv.
+type ErrCodeInfo struct {
+	Code     int64
+	Name     string
+	Category ErrCategory
+	Zh       string // 中文说明
+	En       string
+}
+
+// LookupErrCode returns the description of a documented errcode.
+func LookupErrCode(code int64) (*ErrCodeInfo, bool) {
+	info, ok := errCodeCatalogue[code]
+	return info, ok
+}
+
+// errCodeCategory returns the category of code, or "" if it has none.
+func errCodeCategory(code int64) ErrCategory {
+	if info, ok := errCodeCatalogue[code]; ok {
+		return info.Category
+	}
+	return ""
+}
+
+// APIError is returned when wechat answers with a non-zero errcode. Use
+// errors.As to get it from a returned error, or errors.Is with an APIError
+// of the same errcode, e.g. ErrUserRefuseToAccept.
+type APIError struct {
+	API     string // api name or endpoint
+	ErrCode int64
+	ErrMsg  string
+	RID     string // request id wechat appends to errmsg, for support requests
+}
+
+// NewAPIError returns the *APIError of ce, or nil if ce.ErrCode is zero.
+func NewAPIError(api string, ce CommonError) *APIError {
+	if ce.ErrCode == 0 {
+		return nil
+	}
+	e := &APIError{
+		API:     api,
+		ErrCode: ce.ErrCode,
+		ErrMsg:  ce.ErrMsg,
+	}
+	if i := strings.LastIndex(ce.ErrMsg, "rid: "); i >= 0 {
+		e.RID = strings.TrimSpace(ce.ErrMsg[i+len("rid: "):])
+	}
+	return e
+}
+
+// Error returns a string representation of the error.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s Error , errcode=%d , errmsg=%s", e.API, e.ErrCode, e.ErrMsg)
+}
+
+// Is reports whether target is an *APIError with the same errcode. The API
+// of target is compared too unless it is empty.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	return t.ErrCode == e.ErrCode && (t.API == "" || t.API == e.API)
+}
+
+// Info returns the catalogue entry of the errcode, or nil if it isn't
+// documented.
+func (e *APIError) Info() *ErrCodeInfo {
+	info, _ := LookupErrCode(e.ErrCode)
+	return info
+}
+
+// Category returns the category of the errcode.
+func (e *APIError) Category() ErrCategory {
+	return errCodeCategory(e.ErrCode)
+}
+
 // DecodeWithCommonError DecodeWithCommonError
 func DecodeWithCommonError(apiName string, ce CommonError) (err error) {
-	if ce.ErrCode != 0 {
-		err = fmt.Errorf("%s Error , errcode=%d , errmsg=%s", apiName, ce.ErrCode, ce.ErrMsg)
-		return errors.Wrap(err, "DecodeWithCommonError")
+	if e := NewAPIError(apiName, ce); e != nil {
+		return errors.Wrap(e, "DecodeWithCommonError")
 	}
 	return nil
 }
+
+// AsAPIError returns the *APIError in the chain of err.
+func AsAPIError(err error) (*APIError, bool) {
+	var e *APIError
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// IsErrCode returns true if err is an *APIError with one of codes.
+func IsErrCode(err error, codes ...int64) bool {
+	e, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	for _, code := range codes {
+		if e.ErrCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsErrCategory returns true if err is an *APIError of category.
+func IsErrCategory(err error, category ErrCategory) bool {
+	e, ok := AsAPIError(err)
+	return ok && e.Category() == category
+}
+
+// IsSystemBusy returns true if wechat is busy and the request may succeed
+// later, errcode -1.
+func IsSystemBusy(err error) bool {
+	return IsErrCategory(err, ErrCategoryBusy)
+}
+
+// IsTokenExpired returns true if wechat rejected the access token as
+// invalid or expired.
+func IsTokenExpired(err error) bool {
+	return IsErrCategory(err, ErrCategoryToken)
+}
+
+// IsRateLimited returns true if an api quota or frequency limit is reached.
+func IsRateLimited(err error) bool {
+	return IsErrCategory(err, ErrCategoryRateLimit)
+}
+
+// IsUserRefused returns true if the user can't or doesn't want to receive
+// the message, e.g. 43101.
+func IsUserRefused(err error) bool {
+	return IsErrCategory(err, ErrCategoryUserRefused)
+}
+
+// IsContentRisky returns true if the content security check found risky
+// content, 87014.
+func IsContentRisky(err error) bool {
+	return IsErrCategory(err, ErrCategoryContentRisky)
+}
+
+// peekCommonError decodes the errcode of a JSON response body. It returns
+// nil if the body isn't a JSON object or the errcode is zero.
+func peekCommonError(body []byte) *CommonError {
+	if len(body) == 0 || body[0] != '{' {
+		return nil
+	}
+	ce := new(CommonError)
+	if err := json.Unmarshal(body, ce); err != nil || ce.ErrCode == 0 {
+		return nil
+	}
+	return ce
+}
@@ -0,0 +1,401 @@
+// Code generated by errcode_gen.go from errcode.csv; DO NOT EDIT.
+
+package wechat
+
+// errcodes documented by wechat
+const (
+	// ErrCodeSystemBusy 系统繁忙，此时请开发者稍候再试
+	ErrCodeSystemBusy int64 = -1
+	// ErrCodeInvalidCredential 获取 access_token 时 AppSecret 错误，或者 access_token 无效
+	ErrCodeInvalidCredential int64 = 40001
+	// ErrCodeInvalidGrantType 不合法的凭证类型
+	ErrCodeInvalidGrantType int64 = 40002
+	// ErrCodeInvalidOpenID 不合法的 OpenID
+	ErrCodeInvalidOpenID int64 = 40003
+	// ErrCodeInvalidMediaType 不合法的媒体文件类型
+	ErrCodeInvalidMediaType int64 = 40004
+	// ErrCodeInvalidFileType 不合法的文件类型
+	ErrCodeInvalidFileType int64 = 40005
+	// ErrCodeInvalidMediaSize 不合法的文件大小
+	ErrCodeInvalidMediaSize int64 = 40006
+	// ErrCodeInvalidMediaID 不合法的媒体文件 id
+	ErrCodeInvalidMediaID int64 = 40007
+	// ErrCodeInvalidMessageType 不合法的消息类型
+	ErrCodeInvalidMessageType int64 = 40008
+	// ErrCodeInvalidImageSize 不合法的图片文件大小
+	ErrCodeInvalidImageSize int64 = 40009
+	// ErrCodeInvalidVoiceSize 不合法的语音文件大小
+	ErrCodeInvalidVoiceSize int64 = 40010
+	// ErrCodeInvalidVideoSize 不合法的视频文件大小
+	ErrCodeInvalidVideoSize int64 = 40011
+	// ErrCodeInvalidThumbSize 不合法的缩略图文件大小
+	ErrCodeInvalidThumbSize int64 = 40012
+	// ErrCodeInvalidAppID 不合法的 AppID
+	ErrCodeInvalidAppID int64 = 40013
+	// ErrCodeInvalidAccessToken 不合法的 access_token
+	ErrCodeInvalidAccessToken int64 = 40014
+	// ErrCodeInvalidMenuType 不合法的菜单类型
+	ErrCodeInvalidMenuType int64 = 40015
+	// ErrCodeInvalidButtonSize 不合法的按钮个数
+	ErrCodeInvalidButtonSize int64 = 40016
+	// ErrCodeInvalidButtonType 不合法的按钮类型
+	ErrCodeInvalidButtonType int64 = 40017
+	// ErrCodeInvalidButtonNameSize 不合法的按钮名字长度
+	ErrCodeInvalidButtonNameSize int64 = 40018
+	// ErrCodeInvalidButtonKeySize 不合法的按钮 KEY 长度
+	ErrCodeInvalidButtonKeySize int64 = 40019
+	// ErrCodeInvalidButtonURLSize 不合法的按钮 URL 长度
+	ErrCodeInvalidButtonURLSize int64 = 40020
+	// ErrCodeInvalidSubButtonSize 不合法的子菜单按钮个数
+	ErrCodeInvalidSubButtonSize int64 = 40023
+	// ErrCodeInvalidSubButtonType 不合法的子菜单按钮类型
+	ErrCodeInvalidSubButtonType int64 = 40024
+	// ErrCodeInvalidSubButtonNameSize 不合法的子菜单按钮名字长度
+	ErrCodeInvalidSubButtonNameSize int64 = 40025
+	// ErrCodeInvalidSubButtonKeySize 不合法的子菜单按钮 KEY 长度
+	ErrCodeInvalidSubButtonKeySize int64 = 40026
+	// ErrCodeInvalidSubButtonURLSize 不合法的子菜单按钮 URL 长度
+	ErrCodeInvalidSubButtonURLSize int64 = 40027
+	// ErrCodeInvalidCode 无效的 oauth_code
+	ErrCodeInvalidCode int64 = 40029
+	// ErrCodeInvalidRefreshToken 不合法的 refresh_token
+	ErrCodeInvalidRefreshToken int64 = 40030
+	// ErrCodeInvalidCharset 不合法的请求字符，不能包含 \uxxxx 格式的字符
+	ErrCodeInvalidCharset int64 = 40033
+	// ErrCodeInvalidParameter 不合法的参数
+	ErrCodeInvalidParameter int64 = 40035
+	// ErrCodeInvalidTemplateIDSize 不合法的 template_id 长度
+	ErrCodeInvalidTemplateIDSize int64 = 40036
+	// ErrCodeInvalidTemplateID 不合法的 template_id
+	ErrCodeInvalidTemplateID int64 = 40037
+	// ErrCodeInvalidPackagingType 不合法的请求格式
+	ErrCodeInvalidPackagingType int64 = 40038
+	// ErrCodeInvalidURLSize 不合法的 URL 长度
+	ErrCodeInvalidURLSize int64 = 40039
+	// ErrCodeInvalidURLDomain 无效的 url
+	ErrCodeInvalidURLDomain int64 = 40048
+	// ErrCodeInvalidGroupMsgID 不合法的分组 id
+	ErrCodeInvalidGroupMsgID int64 = 40050
+	// ErrCodeInvalidSubButtonURLDomain 不合法的子菜单按钮 url 域名
+	ErrCodeInvalidSubButtonURLDomain int64 = 40054
+	// ErrCodeInvalidButtonURLDomain 不合法的菜单按钮 url 域名
+	ErrCodeInvalidButtonURLDomain int64 = 40055
+	// ErrCodeInvalidAgentID 不合法的 agentid
+	ErrCodeInvalidAgentID int64 = 40056
+	// ErrCodeInvalidURL 不合法的 url
+	ErrCodeInvalidURL int64 = 40066
+	// ErrCodeInvalidArgs 参数错误
+	ErrCodeInvalidArgs int64 = 40097
+	// ErrCodeInvalidAppSecret 不合法的 AppSecret
+	ErrCodeInvalidAppSecret int64 = 40125
+	// ErrCodeInvalidOpenIDListSize 至少需要同时发送两个用户
+	ErrCodeInvalidOpenIDListSize int64 = 40130
+	// ErrCodeInvalidUsername 微信号不合法
+	ErrCodeInvalidUsername int64 = 40132
+	// ErrCodeInvalidImageFormat 不支持的图片格式
+	ErrCodeInvalidImageFormat int64 = 40137
+	// ErrCodeInvalidIP 调用接口的 IP 地址不在白名单中
+	ErrCodeInvalidIP int64 = 40164
+	// ErrCodeHighRiskUser 高风险等级用户，小程序登录拦截
+	ErrCodeHighRiskUser int64 = 40226
+	// ErrCodeAccessTokenMissing 缺少 access_token 参数
+	ErrCodeAccessTokenMissing int64 = 41001
+	// ErrCodeAppIDMissing 缺少 appid 参数
+	ErrCodeAppIDMissing int64 = 41002
+	// ErrCodeRefreshTokenMissing 缺少 refresh_token 参数
+	ErrCodeRefreshTokenMissing int64 = 41003
+	// ErrCodeAppSecretMissing 缺少 secret 参数
+	ErrCodeAppSecretMissing int64 = 41004
+	// ErrCodeMediaDataMissing 缺少多媒体文件数据
+	ErrCodeMediaDataMissing int64 = 41005
+	// ErrCodeMediaIDMissing 缺少 media_id 参数
+	ErrCodeMediaIDMissing int64 = 41006
+	// ErrCodeSubMenuDataMissing 缺少子菜单数据
+	ErrCodeSubMenuDataMissing int64 = 41007
+	// ErrCodeCodeMissing 缺少 oauth code
+	ErrCodeCodeMissing int64 = 41008
+	// ErrCodeOpenIDMissing 缺少 openid
+	ErrCodeOpenIDMissing int64 = 41009
+	// ErrCodeAccessTokenExpired access_token 超时
+	ErrCodeAccessTokenExpired int64 = 42001
+	// ErrCodeRefreshTokenExpired refresh_token 超时
+	ErrCodeRefreshTokenExpired int64 = 42002
+	// ErrCodeCodeExpired oauth_code 超时
+	ErrCodeCodeExpired int64 = 42003
+	// ErrCodeAccessTokenInvalidated 用户修改微信密码，access_token 和 refresh_token 失效，需要重新授权
+	ErrCodeAccessTokenInvalidated int64 = 42007
+	// ErrCodeRequireGetMethod 需要 GET 请求
+	ErrCodeRequireGetMethod int64 = 43001
+	// ErrCodeRequirePostMethod 需要 POST 请求
+	ErrCodeRequirePostMethod int64 = 43002
+	// ErrCodeRequireHTTPS 需要 HTTPS 请求
+	ErrCodeRequireHTTPS int64 = 43003
+	// ErrCodeRequireSubscribe 需要接收者关注
+	ErrCodeRequireSubscribe int64 = 43004
+	// ErrCodeRequireFriendRelations 需要好友关系
+	ErrCodeRequireFriendRelations int64 = 43005
+	// ErrCodeRequireRemoveBlacklist 需要将接收者从黑名单中移除
+	ErrCodeRequireRemoveBlacklist int64 = 43019
+	// ErrCodeUserRefuseToAccept 用户拒绝接受消息，如果用户之前曾经订阅过，则表示用户取消了订阅关系
+	ErrCodeUserRefuseToAccept int64 = 43101
+	// ErrCodeEmptyMediaData 多媒体文件为空
+	ErrCodeEmptyMediaData int64 = 44001
+	// ErrCodeEmptyPostData POST 的数据包为空
+	ErrCodeEmptyPostData int64 = 44002
+	// ErrCodeEmptyNewsData 图文消息内容为空
+	ErrCodeEmptyNewsData int64 = 44003
+	// ErrCodeEmptyContent 文本消息内容为空
+	ErrCodeEmptyContent int64 = 44004
+	// ErrCodeMediaSizeOutOfLimit 多媒体文件大小超过限制
+	ErrCodeMediaSizeOutOfLimit int64 = 45001
+	// ErrCodeContentSizeOutOfLimit 消息内容超过限制
+	ErrCodeContentSizeOutOfLimit int64 = 45002
+	// ErrCodeTitleSizeOutOfLimit 标题字段超过限制
+	ErrCodeTitleSizeOutOfLimit int64 = 45003
+	// ErrCodeDescriptionSizeOutOfLimit 描述字段超过限制
+	ErrCodeDescriptionSizeOutOfLimit int64 = 45004
+	// ErrCodeURLSizeOutOfLimit 链接字段超过限制
+	ErrCodeURLSizeOutOfLimit int64 = 45005
+	// ErrCodePicURLSizeOutOfLimit 图片链接字段超过限制
+	ErrCodePicURLSizeOutOfLimit int64 = 45006
+	// ErrCodePlaytimeOutOfLimit 语音播放时间超过限制
+	ErrCodePlaytimeOutOfLimit int64 = 45007
+	// ErrCodeArticleSizeOutOfLimit 图文消息超过限制
+	ErrCodeArticleSizeOutOfLimit int64 = 45008
+	// ErrCodeAPIFreqOutOfLimit 接口调用超过限制
+	ErrCodeAPIFreqOutOfLimit int64 = 45009
+	// ErrCodeCreateMenuLimit 创建菜单个数超过限制
+	ErrCodeCreateMenuLimit int64 = 45010
+	// ErrCodeAPIMinuteQuotaLimit API 调用太频繁，请稍候再试
+	ErrCodeAPIMinuteQuotaLimit int64 = 45011
+	// ErrCodeResponseOutOfTimeLimit 回复时间超过限制
+	ErrCodeResponseOutOfTimeLimit int64 = 45015
+	// ErrCodeCannotModifySystemGroup 系统分组，不允许修改
+	ErrCodeCannotModifySystemGroup int64 = 45016
+	// ErrCodeGroupNameTooLong 分组名字过长
+	ErrCodeGroupNameTooLong int64 = 45017
+	// ErrCodeTooManyGroups 分组数量超过上限
+	ErrCodeTooManyGroups int64 = 45018
+	// ErrCodeOutOfResponseCountLimit 客服接口下行条数超过上限
+	ErrCodeOutOfResponseCountLimit int64 = 45047
+	// ErrCodeTooManyTags 创建的标签数过多，请注意不能超过 100 个
+	ErrCodeTooManyTags int64 = 45056
+	// ErrCodeTagTooManyFans 该标签下粉丝数超过 10w，不允许直接删除
+	ErrCodeTagTooManyFans int64 = 45057
+	// ErrCodeCannotModifySystemTag 不能修改 0/1/2 这三个系统默认保留的标签
+	ErrCodeCannotModifySystemTag int64 = 45058
+	// ErrCodeUserTooManyTags 有粉丝身上的标签数已经超过限制，即超过 20 个
+	ErrCodeUserTooManyTags int64 = 45059
+	// ErrCodeMenuWeappNoPermission 创建菜单包含未关联的小程序
+	ErrCodeMenuWeappNoPermission int64 = 45064
+	// ErrCodeClientMsgIDExist 相同 clientmsgid 已存在群发记录
+	ErrCodeClientMsgIDExist int64 = 45065
+	// ErrCodeTagNameExist 标签名非法，请注意不能和其他标签重名
+	ErrCodeTagNameExist int64 = 45157
+	// ErrCodeTagNameTooLong 标签名长度超过 30 个字节
+	ErrCodeTagNameTooLong int64 = 45158
+	// ErrCodeMediaDataNotExist 不存在媒体数据
+	ErrCodeMediaDataNotExist int64 = 46001
+	// ErrCodeMenuVersionNotExist 不存在的菜单版本
+	ErrCodeMenuVersionNotExist int64 = 46002
+	// ErrCodeMenuDataNotExist 不存在的菜单数据
+	ErrCodeMenuDataNotExist int64 = 46003
+	// ErrCodeUserNotExist 不存在的用户
+	ErrCodeUserNotExist int64 = 46004
+	// ErrCodeDataFormatError 解析 JSON/XML 内容错误
+	ErrCodeDataFormatError int64 = 47001
+	// ErrCodeArgumentInvalid 模板参数不准确，可能为空或者不满足规则
+	ErrCodeArgumentInvalid int64 = 47003
+	// ErrCodeAPIUnauthorized api 功能未授权，请确认公众号已获得该接口
+	ErrCodeAPIUnauthorized int64 = 48001
+	// ErrCodeAPIForbidden api 接口被封禁
+	ErrCodeAPIForbidden int64 = 48004
+	// ErrCodeAPIForbiddenForIrrelevance api 禁止删除被自动回复和自定义菜单引用的素材
+	ErrCodeAPIForbiddenForIrrelevance int64 = 48005
+	// ErrCodeAPIForbiddenForClearQuota api 禁止清零调用次数，因为清零次数达到上限
+	ErrCodeAPIForbiddenForClearQuota int64 = 48006
+	// ErrCodeUserUnauthorized 用户未授权该 api
+	ErrCodeUserUnauthorized int64 = 50001
+	// ErrCodeUserLimited 用户受限，可能是违规后接口被封禁
+	ErrCodeUserLimited int64 = 50002
+	// ErrCodeNoPrivilege 指定的成员/部门/标签参数无权限
+	ErrCodeNoPrivilege int64 = 60011
+	// ErrCodeNotAllowedIP 不安全的访问 IP
+	ErrCodeNotAllowedIP int64 = 60020
+	// ErrCodeUserIDNotFound UserID 不存在
+	ErrCodeUserIDNotFound int64 = 60111
+	// ErrCodeInvalidParameterKF 参数错误
+	ErrCodeInvalidParameterKF int64 = 61451
+	// ErrCodeInvalidKFAccount 客服帐号名不合法
+	ErrCodeInvalidKFAccount int64 = 61453
+	// ErrCodeUserIDAllInvalid UserID、部门 ID、标签 ID 全部非法或无权限
+	ErrCodeUserIDAllInvalid int64 = 81013
+	// ErrCodeTemplateNotFound 找不到模板
+	ErrCodeTemplateNotFound int64 = 85064
+	// ErrCodeLinkRulesInvalid 链接错误
+	ErrCodeLinkRulesInvalid int64 = 85066
+	// ErrCodeRiskyContent 内容含有违法违规内容
+	ErrCodeRiskyContent int64 = 87014
+	// ErrCodeRiskyMediaSize 内容审核的媒体文件大小超过限制
+	ErrCodeRiskyMediaSize int64 = 87015
+	// ErrCodeAwaitingAdminConfirm 此 IP 正在等待管理员确认
+	ErrCodeAwaitingAdminConfirm int64 = 89501
+	// ErrCodeIPCallNeedsConfirm 此 IP 调用需要管理员确认
+	ErrCodeIPCallNeedsConfirm int64 = 89503
+	// ErrCodeIPCallBlocked24h 24 小时内该 IP 被管理员拒绝调用两次，24 小时内不可再使用该 IP 调用
+	ErrCodeIPCallBlocked24h int64 = 89506
+	// ErrCodeIPCallBlocked1h 1 小时内该 IP 被管理员拒绝调用一次，1 小时内不可再使用该 IP 调用
+	ErrCodeIPCallBlocked1h int64 = 89507
+	// ErrCodeAccountBanned 此账号已被封禁，无法操作
+	ErrCodeAccountBanned int64 = 200011
+	// ErrCodeTemplateParamInvalid 模板 tid 参数错误
+	ErrCodeTemplateParamInvalid int64 = 200014
+	// ErrCodeStartParamInvalid start 参数错误
+	ErrCodeStartParamInvalid int64 = 200016
+	// ErrCodeLimitParamInvalid limit 参数错误
+	ErrCodeLimitParamInvalid int64 = 200017
+	// ErrCodeCategoryIDInvalid 类目 ids 缺失
+	ErrCodeCategoryIDInvalid int64 = 200018
+	// ErrCodeCategoryIDWrong 类目 ids 不合法
+	ErrCodeCategoryIDWrong int64 = 200019
+	// ErrCodeKidListInvalid 关键词列表 kidList 参数错误
+	ErrCodeKidListInvalid int64 = 200020
+	// ErrCodeSceneDescInvalid 场景描述 sceneDesc 参数错误
+	ErrCodeSceneDescInvalid int64 = 200021
+	// ErrCodeAppIDAccessTokenMismatch 调用的接口 access_token 与 AppID 不匹配
+	ErrCodeAppIDAccessTokenMismatch int64 = 301002
+)
+
+// errCodeCatalogue describes the documented errcodes.
+var errCodeCatalogue = map[int64]*ErrCodeInfo{
+	ErrCodeSystemBusy:                 {Code: ErrCodeSystemBusy, Name: "SystemBusy", Category: ErrCategoryBusy, Zh: "系统繁忙，此时请开发者稍候再试", En: "system is busy"},
+	ErrCodeInvalidCredential:          {Code: ErrCodeInvalidCredential, Name: "InvalidCredential", Category: ErrCategoryToken, Zh: "获取 access_token 时 AppSecret 错误，或者 access_token 无效", En: "invalid credential"},
+	ErrCodeInvalidGrantType:           {Code: ErrCodeInvalidGrantType, Name: "InvalidGrantType", Zh: "不合法的凭证类型", En: "invalid grant_type"},
+	ErrCodeInvalidOpenID:              {Code: ErrCodeInvalidOpenID, Name: "InvalidOpenID", Zh: "不合法的 OpenID", En: "invalid openid"},
+	ErrCodeInvalidMediaType:           {Code: ErrCodeInvalidMediaType, Name: "InvalidMediaType", Zh: "不合法的媒体文件类型", En: "invalid media type"},
+	ErrCodeInvalidFileType:            {Code: ErrCodeInvalidFileType, Name: "InvalidFileType", Zh: "不合法的文件类型", En: "invalid file type"},
+	ErrCodeInvalidMediaSize:           {Code: ErrCodeInvalidMediaSize, Name: "InvalidMediaSize", Zh: "不合法的文件大小", En: "invalid media size"},
+	ErrCodeInvalidMediaID:             {Code: ErrCodeInvalidMediaID, Name: "InvalidMediaID", Zh: "不合法的媒体文件 id", En: "invalid media_id"},
+	ErrCodeInvalidMessageType:         {Code: ErrCodeInvalidMessageType, Name: "InvalidMessageType", Zh: "不合法的消息类型", En: "invalid message type"},
+	ErrCodeInvalidImageSize:           {Code: ErrCodeInvalidImageSize, Name: "InvalidImageSize", Zh: "不合法的图片文件大小", En: "invalid image size"},
+	ErrCodeInvalidVoiceSize:           {Code: ErrCodeInvalidVoiceSize, Name: "InvalidVoiceSize", Zh: "不合法的语音文件大小", En: "invalid voice size"},
+	ErrCodeInvalidVideoSize:           {Code: ErrCodeInvalidVideoSize, Name: "InvalidVideoSize", Zh: "不合法的视频文件大小", En: "invalid video size"},
+	ErrCodeInvalidThumbSize:           {Code: ErrCodeInvalidThumbSize, Name: "InvalidThumbSize", Zh: "不合法的缩略图文件大小", En: "invalid thumb size"},
+	ErrCodeInvalidAppID:               {Code: ErrCodeInvalidAppID, Name: "InvalidAppID", Zh: "不合法的 AppID", En: "invalid appid"},
+	ErrCodeInvalidAccessToken:         {Code: ErrCodeInvalidAccessToken, Name: "InvalidAccessToken", Category: ErrCategoryToken, Zh: "不合法的 access_token", En: "invalid access_token"},
+	ErrCodeInvalidMenuType:            {Code: ErrCodeInvalidMenuType, Name: "InvalidMenuType", Zh: "不合法的菜单类型", En: "invalid menu type"},
+	ErrCodeInvalidButtonSize:          {Code: ErrCodeInvalidButtonSize, Name: "InvalidButtonSize", Zh: "不合法的按钮个数", En: "invalid button size"},
+	ErrCodeInvalidButtonType:          {Code: ErrCodeInvalidButtonType, Name: "InvalidButtonType", Zh: "不合法的按钮类型", En: "invalid button type"},
+	ErrCodeInvalidButtonNameSize:      {Code: ErrCodeInvalidButtonNameSize, Name: "InvalidButtonNameSize", Zh: "不合法的按钮名字长度", En: "invalid button name size"},
+	ErrCodeInvalidButtonKeySize:       {Code: ErrCodeInvalidButtonKeySize, Name: "InvalidButtonKeySize", Zh: "不合法的按钮 KEY 长度", En: "invalid button key size"},
+	ErrCodeInvalidButtonURLSize:       {Code: ErrCodeInvalidButtonURLSize, Name: "InvalidButtonURLSize", Zh: "不合法的按钮 URL 长度", En: "invalid button url size"},
+	ErrCodeInvalidSubButtonSize:       {Code: ErrCodeInvalidSubButtonSize, Name: "InvalidSubButtonSize", Zh: "不合法的子菜单按钮个数", En: "invalid sub button size"},
+	ErrCodeInvalidSubButtonType:       {Code: ErrCodeInvalidSubButtonType, Name: "InvalidSubButtonType", Zh: "不合法的子菜单按钮类型", En: "invalid sub button type"},
+	ErrCodeInvalidSubButtonNameSize:   {Code: ErrCodeInvalidSubButtonNameSize, Name: "InvalidSubButtonNameSize", Zh: "不合法的子菜单按钮名字长度", En: "invalid sub button name size"},
+	ErrCodeInvalidSubButtonKeySize:    {Code: ErrCodeInvalidSubButtonKeySize, Name: "InvalidSubButtonKeySize", Zh: "不合法的子菜单按钮 KEY 长度", En: "invalid sub button key size"},
+	ErrCodeInvalidSubButtonURLSize:    {Code: ErrCodeInvalidSubButtonURLSize, Name: "InvalidSubButtonURLSize", Zh: "不合法的子菜单按钮 URL 长度", En: "invalid sub button url size"},
+	ErrCodeInvalidCode:                {Code: ErrCodeInvalidCode, Name: "InvalidCode", Zh: "无效的 oauth_code", En: "invalid code"},
+	ErrCodeInvalidRefreshToken:        {Code: ErrCodeInvalidRefreshToken, Name: "InvalidRefreshToken", Zh: "不合法的 refresh_token", En: "invalid refresh_token"},
+	ErrCodeInvalidCharset:             {Code: ErrCodeInvalidCharset, Name: "InvalidCharset", Zh: "不合法的请求字符，不能包含 \\uxxxx 格式的字符", En: "invalid charset"},
+	ErrCodeInvalidParameter:           {Code: ErrCodeInvalidParameter, Name: "InvalidParameter", Zh: "不合法的参数", En: "invalid parameter"},
+	ErrCodeInvalidTemplateIDSize:      {Code: ErrCodeInvalidTemplateIDSize, Name: "InvalidTemplateIDSize", Zh: "不合法的 template_id 长度", En: "invalid template_id size"},
+	ErrCodeInvalidTemplateID:          {Code: ErrCodeInvalidTemplateID, Name: "InvalidTemplateID", Zh: "不合法的 template_id", En: "invalid template_id"},
+	ErrCodeInvalidPackagingType:       {Code: ErrCodeInvalidPackagingType, Name: "InvalidPackagingType", Zh: "不合法的请求格式", En: "invalid packaging type"},
+	ErrCodeInvalidURLSize:             {Code: ErrCodeInvalidURLSize, Name: "InvalidURLSize", Zh: "不合法的 URL 长度", En: "invalid url size"},
+	ErrCodeInvalidURLDomain:           {Code: ErrCodeInvalidURLDomain, Name: "InvalidURLDomain", Zh: "无效的 url", En: "invalid url domain"},
+	ErrCodeInvalidGroupMsgID:          {Code: ErrCodeInvalidGroupMsgID, Name: "InvalidGroupMsgID", Zh: "不合法的分组 id", En: "invalid group msg id"},
+	ErrCodeInvalidSubButtonURLDomain:  {Code: ErrCodeInvalidSubButtonURLDomain, Name: "InvalidSubButtonURLDomain", Zh: "不合法的子菜单按钮 url 域名", En: "invalid sub button url domain"},
+	ErrCodeInvalidButtonURLDomain:     {Code: ErrCodeInvalidButtonURLDomain, Name: "InvalidButtonURLDomain", Zh: "不合法的菜单按钮 url 域名", En: "invalid button url domain"},
+	ErrCodeInvalidAgentID:             {Code: ErrCodeInvalidAgentID, Name: "InvalidAgentID", Zh: "不合法的 agentid", En: "invalid agentid"},
+	ErrCodeInvalidURL:                 {Code: ErrCodeInvalidURL, Name: "InvalidURL", Zh: "不合法的 url", En: "invalid url"},
+	ErrCodeInvalidArgs:                {Code: ErrCodeInvalidArgs, Name: "InvalidArgs", Zh: "参数错误", En: "invalid args"},
+	ErrCodeInvalidAppSecret:           {Code: ErrCodeInvalidAppSecret, Name: "InvalidAppSecret", Zh: "不合法的 AppSecret", En: "invalid appsecret"},
+	ErrCodeInvalidOpenIDListSize:      {Code: ErrCodeInvalidOpenIDListSize, Name: "InvalidOpenIDListSize", Zh: "至少需要同时发送两个用户", En: "invalid openid list size"},
+	ErrCodeInvalidUsername:            {Code: ErrCodeInvalidUsername, Name: "InvalidUsername", Zh: "微信号不合法", En: "invalid username"},
+	ErrCodeInvalidImageFormat:         {Code: ErrCodeInvalidImageFormat, Name: "InvalidImageFormat", Zh: "不支持的图片格式", En: "invalid image format"},
+	ErrCodeInvalidIP:                  {Code: ErrCodeInvalidIP, Name: "InvalidIP", Zh: "调用接口的 IP 地址不在白名单中", En: "invalid ip"},
+	ErrCodeHighRiskUser:               {Code: ErrCodeHighRiskUser, Name: "HighRiskUser", Zh: "高风险等级用户，小程序登录拦截", En: "high risk user"},
+	ErrCodeAccessTokenMissing:         {Code: ErrCodeAccessTokenMissing, Name: "AccessTokenMissing", Zh: "缺少 access_token 参数", En: "access_token missing"},
+	ErrCodeAppIDMissing:               {Code: ErrCodeAppIDMissing, Name: "AppIDMissing", Zh: "缺少 appid 参数", En: "appid missing"},
+	ErrCodeRefreshTokenMissing:        {Code: ErrCodeRefreshTokenMissing, Name: "RefreshTokenMissing", Zh: "缺少 refresh_token 参数", En: "refresh_token missing"},
+	ErrCodeAppSecretMissing:           {Code: ErrCodeAppSecretMissing, Name: "AppSecretMissing", Zh: "缺少 secret 参数", En: "appsecret missing"},
+	ErrCodeMediaDataMissing:           {Code: ErrCodeMediaDataMissing, Name: "MediaDataMissing", Zh: "缺少多媒体文件数据", En: "media data missing"},
+	ErrCodeMediaIDMissing:             {Code: ErrCodeMediaIDMissing, Name: "MediaIDMissing", Zh: "缺少 media_id 参数", En: "media_id missing"},
+	ErrCodeSubMenuDataMissing:         {Code: ErrCodeSubMenuDataMissing, Name: "SubMenuDataMissing", Zh: "缺少子菜单数据", En: "sub menu data missing"},
+	ErrCodeCodeMissing:                {Code: ErrCodeCodeMissing, Name: "CodeMissing", Zh: "缺少 oauth code", En: "missing code"},
+	ErrCodeOpenIDMissing:              {Code: ErrCodeOpenIDMissing, Name: "OpenIDMissing", Zh: "缺少 openid", En: "missing openid"},
+	ErrCodeAccessTokenExpired:         {Code: ErrCodeAccessTokenExpired, Name: "AccessTokenExpired", Category: ErrCategoryToken, Zh: "access_token 超时", En: "access_token expired"},
+	ErrCodeRefreshTokenExpired:        {Code: ErrCodeRefreshTokenExpired, Name: "RefreshTokenExpired", Zh: "refresh_token 超时", En: "refresh_token expired"},
+	ErrCodeCodeExpired:                {Code: ErrCodeCodeExpired, Name: "CodeExpired", Zh: "oauth_code 超时", En: "code expired"},
+	ErrCodeAccessTokenInvalidated:     {Code: ErrCodeAccessTokenInvalidated, Name: "AccessTokenInvalidated", Zh: "用户修改微信密码，access_token 和 refresh_token 失效，需要重新授权", En: "access_token and refresh_token invalidated"},
+	ErrCodeRequireGetMethod:           {Code: ErrCodeRequireGetMethod, Name: "RequireGetMethod", Zh: "需要 GET 请求", En: "require GET method"},
+	ErrCodeRequirePostMethod:          {Code: ErrCodeRequirePostMethod, Name: "RequirePostMethod", Zh: "需要 POST 请求", En: "require POST method"},
+	ErrCodeRequireHTTPS:               {Code: ErrCodeRequireHTTPS, Name: "RequireHTTPS", Zh: "需要 HTTPS 请求", En: "require https"},
+	ErrCodeRequireSubscribe:           {Code: ErrCodeRequireSubscribe, Name: "RequireSubscribe", Category: ErrCategoryUserRefused, Zh: "需要接收者关注", En: "require subscribe"},
+	ErrCodeRequireFriendRelations:     {Code: ErrCodeRequireFriendRelations, Name: "RequireFriendRelations", Category: ErrCategoryUserRefused, Zh: "需要好友关系", En: "require friend relations"},
+	ErrCodeRequireRemoveBlacklist:     {Code: ErrCodeRequireRemoveBlacklist, Name: "RequireRemoveBlacklist", Category: ErrCategoryUserRefused, Zh: "需要将接收者从黑名单中移除", En: "require remove blacklist"},
+	ErrCodeUserRefuseToAccept:         {Code: ErrCodeUserRefuseToAccept, Name: "UserRefuseToAccept", Category: ErrCategoryUserRefused, Zh: "用户拒绝接受消息，如果用户之前曾经订阅过，则表示用户取消了订阅关系", En: "user refuse to accept the msg"},
+	ErrCodeEmptyMediaData:             {Code: ErrCodeEmptyMediaData, Name: "EmptyMediaData", Zh: "多媒体文件为空", En: "empty media data"},
+	ErrCodeEmptyPostData:              {Code: ErrCodeEmptyPostData, Name: "EmptyPostData", Zh: "POST 的数据包为空", En: "empty post data"},
+	ErrCodeEmptyNewsData:              {Code: ErrCodeEmptyNewsData, Name: "EmptyNewsData", Zh: "图文消息内容为空", En: "empty news data"},
+	ErrCodeEmptyContent:               {Code: ErrCodeEmptyContent, Name: "EmptyContent", Zh: "文本消息内容为空", En: "empty content"},
+	ErrCodeMediaSizeOutOfLimit:        {Code: ErrCodeMediaSizeOutOfLimit, Name: "MediaSizeOutOfLimit", Zh: "多媒体文件大小超过限制", En: "media size out of limit"},
+	ErrCodeContentSizeOutOfLimit:      {Code: ErrCodeContentSizeOutOfLimit, Name: "ContentSizeOutOfLimit", Zh: "消息内容超过限制", En: "content size out of limit"},
+	ErrCodeTitleSizeOutOfLimit:        {Code: ErrCodeTitleSizeOutOfLimit, Name: "TitleSizeOutOfLimit", Zh: "标题字段超过限制", En: "title size out of limit"},
+	ErrCodeDescriptionSizeOutOfLimit:  {Code: ErrCodeDescriptionSizeOutOfLimit, Name: "DescriptionSizeOutOfLimit", Zh: "描述字段超过限制", En: "description size out of limit"},
+	ErrCodeURLSizeOutOfLimit:          {Code: ErrCodeURLSizeOutOfLimit, Name: "URLSizeOutOfLimit", Zh: "链接字段超过限制", En: "url size out of limit"},
+	ErrCodePicURLSizeOutOfLimit:       {Code: ErrCodePicURLSizeOutOfLimit, Name: "PicURLSizeOutOfLimit", Zh: "图片链接字段超过限制", En: "picurl size out of limit"},
+	ErrCodePlaytimeOutOfLimit:         {Code: ErrCodePlaytimeOutOfLimit, Name: "PlaytimeOutOfLimit", Zh: "语音播放时间超过限制", En: "playtime out of limit"},
+	ErrCodeArticleSizeOutOfLimit:      {Code: ErrCodeArticleSizeOutOfLimit, Name: "ArticleSizeOutOfLimit", Zh: "图文消息超过限制", En: "article size out of limit"},
+	ErrCodeAPIFreqOutOfLimit:          {Code: ErrCodeAPIFreqOutOfLimit, Name: "APIFreqOutOfLimit", Category: ErrCategoryRateLimit, Zh: "接口调用超过限制", En: "reach max api daily quota limit"},
+	ErrCodeCreateMenuLimit:            {Code: ErrCodeCreateMenuLimit, Name: "CreateMenuLimit", Zh: "创建菜单个数超过限制", En: "create menu limit"},
+	ErrCodeAPIMinuteQuotaLimit:        {Code: ErrCodeAPIMinuteQuotaLimit, Name: "APIMinuteQuotaLimit", Category: ErrCategoryRateLimit, Zh: "API 调用太频繁，请稍候再试", En: "api minute-quota reach limit"},
+	ErrCodeResponseOutOfTimeLimit:     {Code: ErrCodeResponseOutOfTimeLimit, Name: "ResponseOutOfTimeLimit", Category: ErrCategoryUserRefused, Zh: "回复时间超过限制", En: "response out of time limit"},
+	ErrCodeCannotModifySystemGroup:    {Code: ErrCodeCannotModifySystemGroup, Name: "CannotModifySystemGroup", Zh: "系统分组，不允许修改", En: "can't modify sys group"},
+	ErrCodeGroupNameTooLong:           {Code: ErrCodeGroupNameTooLong, Name: "GroupNameTooLong", Zh: "分组名字过长", En: "can't set group name too long sys group"},
+	ErrCodeTooManyGroups:              {Code: ErrCodeTooManyGroups, Name: "TooManyGroups", Zh: "分组数量超过上限", En: "too many group now"},
+	ErrCodeOutOfResponseCountLimit:    {Code: ErrCodeOutOfResponseCountLimit, Name: "OutOfResponseCountLimit", Category: ErrCategoryRateLimit, Zh: "客服接口下行条数超过上限", En: "out of response count limit"},
+	ErrCodeTooManyTags:                {Code: ErrCodeTooManyTags, Name: "TooManyTags", Zh: "创建的标签数过多，请注意不能超过 100 个", En: "too many tags now"},
+	ErrCodeTagTooManyFans:             {Code: ErrCodeTagTooManyFans, Name: "TagTooManyFans", Zh: "该标签下粉丝数超过 10w，不允许直接删除", En: "can't delete the tag that has too many fans"},
+	ErrCodeCannotModifySystemTag:      {Code: ErrCodeCannotModifySystemTag, Name: "CannotModifySystemTag", Zh: "不能修改 0/1/2 这三个系统默认保留的标签", En: "can't modify sys tag"},
+	ErrCodeUserTooManyTags:            {Code: ErrCodeUserTooManyTags, Name: "UserTooManyTags", Zh: "有粉丝身上的标签数已经超过限制，即超过 20 个", En: "too many tag now"},
+	ErrCodeMenuWeappNoPermission:      {Code: ErrCodeMenuWeappNoPermission, Name: "MenuWeappNoPermission", Zh: "创建菜单包含未关联的小程序", En: "no permission to use weapp in menu"},
+	ErrCodeClientMsgIDExist:           {Code: ErrCodeClientMsgIDExist, Name: "ClientMsgIDExist", Zh: "相同 clientmsgid 已存在群发记录", En: "clientmsgid exist"},
+	ErrCodeTagNameExist:               {Code: ErrCodeTagNameExist, Name: "TagNameExist", Zh: "标签名非法，请注意不能和其他标签重名", En: "invalid tag name"},
+	ErrCodeTagNameTooLong:             {Code: ErrCodeTagNameTooLong, Name: "TagNameTooLong", Zh: "标签名长度超过 30 个字节", En: "tag name too long"},
+	ErrCodeMediaDataNotExist:          {Code: ErrCodeMediaDataNotExist, Name: "MediaDataNotExist", Zh: "不存在媒体数据", En: "media data no exist"},
+	ErrCodeMenuVersionNotExist:        {Code: ErrCodeMenuVersionNotExist, Name: "MenuVersionNotExist", Zh: "不存在的菜单版本", En: "menu version no exist"},
+	ErrCodeMenuDataNotExist:           {Code: ErrCodeMenuDataNotExist, Name: "MenuDataNotExist", Zh: "不存在的菜单数据", En: "menu no exist"},
+	ErrCodeUserNotExist:               {Code: ErrCodeUserNotExist, Name: "UserNotExist", Zh: "不存在的用户", En: "user no exist"},
+	ErrCodeDataFormatError:            {Code: ErrCodeDataFormatError, Name: "DataFormatError", Zh: "解析 JSON/XML 内容错误", En: "data format error"},
+	ErrCodeArgumentInvalid:            {Code: ErrCodeArgumentInvalid, Name: "ArgumentInvalid", Zh: "模板参数不准确，可能为空或者不满足规则", En: "argument invalid"},
+	ErrCodeAPIUnauthorized:            {Code: ErrCodeAPIUnauthorized, Name: "APIUnauthorized", Zh: "api 功能未授权，请确认公众号已获得该接口", En: "api unauthorized"},
+	ErrCodeAPIForbidden:               {Code: ErrCodeAPIForbidden, Name: "APIForbidden", Zh: "api 接口被封禁", En: "api forbidden"},
+	ErrCodeAPIForbiddenForIrrelevance: {Code: ErrCodeAPIForbiddenForIrrelevance, Name: "APIForbiddenForIrrelevance", Zh: "api 禁止删除被自动回复和自定义菜单引用的素材", En: "forbid to delete material used by auto-reply or menu"},
+	ErrCodeAPIForbiddenForClearQuota:  {Code: ErrCodeAPIForbiddenForClearQuota, Name: "APIForbiddenForClearQuota", Zh: "api 禁止清零调用次数，因为清零次数达到上限", En: "forbid to clear quota because of reaching the limit"},
+	ErrCodeUserUnauthorized:           {Code: ErrCodeUserUnauthorized, Name: "UserUnauthorized", Zh: "用户未授权该 api", En: "user unauthorized"},
+	ErrCodeUserLimited:                {Code: ErrCodeUserLimited, Name: "UserLimited", Zh: "用户受限，可能是违规后接口被封禁", En: "user limited"},
+	ErrCodeNoPrivilege:                {Code: ErrCodeNoPrivilege, Name: "NoPrivilege", Zh: "指定的成员/部门/标签参数无权限", En: "no privilege to access/modify contact/party/agent"},
+	ErrCodeNotAllowedIP:               {Code: ErrCodeNotAllowedIP, Name: "NotAllowedIP", Zh: "不安全的访问 IP", En: "not allow to access from your ip"},
+	ErrCodeUserIDNotFound:             {Code: ErrCodeUserIDNotFound, Name: "UserIDNotFound", Zh: "UserID 不存在", En: "userid not found"},
+	ErrCodeInvalidParameterKF:         {Code: ErrCodeInvalidParameterKF, Name: "InvalidParameterKF", Zh: "参数错误", En: "invalid parameter"},
+	ErrCodeInvalidKFAccount:           {Code: ErrCodeInvalidKFAccount, Name: "InvalidKFAccount", Zh: "客服帐号名不合法", En: "invalid kf_account"},
+	ErrCodeUserIDAllInvalid:           {Code: ErrCodeUserIDAllInvalid, Name: "UserIDAllInvalid", Zh: "UserID、部门 ID、标签 ID 全部非法或无权限", En: "user & party & tag all invalid"},
+	ErrCodeTemplateNotFound:           {Code: ErrCodeTemplateNotFound, Name: "TemplateNotFound", Zh: "找不到模板", En: "template not found"},
+	ErrCodeLinkRulesInvalid:           {Code: ErrCodeLinkRulesInvalid, Name: "LinkRulesInvalid", Zh: "链接错误", En: "link rules invalid"},
+	ErrCodeRiskyContent:               {Code: ErrCodeRiskyContent, Name: "RiskyContent", Category: ErrCategoryContentRisky, Zh: "内容含有违法违规内容", En: "risky content"},
+	ErrCodeRiskyMediaSize:             {Code: ErrCodeRiskyMediaSize, Name: "RiskyMediaSize", Zh: "内容审核的媒体文件大小超过限制", En: "risky media size"},
+	ErrCodeAwaitingAdminConfirm:       {Code: ErrCodeAwaitingAdminConfirm, Name: "AwaitingAdminConfirm", Zh: "此 IP 正在等待管理员确认", En: "awaiting admin confirm"},
+	ErrCodeIPCallNeedsConfirm:         {Code: ErrCodeIPCallNeedsConfirm, Name: "IPCallNeedsConfirm", Zh: "此 IP 调用需要管理员确认", En: "ip call needs admin confirm"},
+	ErrCodeIPCallBlocked24h:           {Code: ErrCodeIPCallBlocked24h, Name: "IPCallBlocked24h", Zh: "24 小时内该 IP 被管理员拒绝调用两次，24 小时内不可再使用该 IP 调用", En: "ip blocked for 24 hours"},
+	ErrCodeIPCallBlocked1h:            {Code: ErrCodeIPCallBlocked1h, Name: "IPCallBlocked1h", Zh: "1 小时内该 IP 被管理员拒绝调用一次，1 小时内不可再使用该 IP 调用", En: "ip blocked for 1 hour"},
+	ErrCodeAccountBanned:              {Code: ErrCodeAccountBanned, Name: "AccountBanned", Zh: "此账号已被封禁，无法操作", En: "account banned"},
+	ErrCodeTemplateParamInvalid:       {Code: ErrCodeTemplateParamInvalid, Name: "TemplateParamInvalid", Zh: "模板 tid 参数错误", En: "invalid template tid"},
+	ErrCodeStartParamInvalid:          {Code: ErrCodeStartParamInvalid, Name: "StartParamInvalid", Zh: "start 参数错误", En: "invalid start param"},
+	ErrCodeLimitParamInvalid:          {Code: ErrCodeLimitParamInvalid, Name: "LimitParamInvalid", Zh: "limit 参数错误", En: "invalid limit param"},
+	ErrCodeCategoryIDInvalid:          {Code: ErrCodeCategoryIDInvalid, Name: "CategoryIDInvalid", Zh: "类目 ids 缺失", En: "category ids missing"},
+	ErrCodeCategoryIDWrong:            {Code: ErrCodeCategoryIDWrong, Name: "CategoryIDWrong", Zh: "类目 ids 不合法", En: "invalid category ids"},
+	ErrCodeKidListInvalid:             {Code: ErrCodeKidListInvalid, Name: "KidListInvalid", Zh: "关键词列表 kidList 参数错误", En: "invalid kidList"},
+	ErrCodeSceneDescInvalid:           {Code: ErrCodeSceneDescInvalid, Name: "SceneDescInvalid", Zh: "场景描述 sceneDesc 参数错误", En: "invalid sceneDesc"},
+	ErrCodeAppIDAccessTokenMismatch:   {Code: ErrCodeAppIDAccessTokenMismatch, Name: "AppIDAccessTokenMismatch", Zh: "调用的接口 access_token 与 AppID 不匹配", En: "access_token does not match appid"},
+}
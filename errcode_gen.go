@@ -0,0 +1,96 @@
+//go:build ignore
+// +build ignore
+
+// errcode_gen generates errcode_catalogue.go from errcode.csv, run it with
+// go generate.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"text/template"
+)
+
+var categories = map[string]string{
+	"":          "",
+	"busy":      "ErrCategoryBusy",
+	"token":     "ErrCategoryToken",
+	"ratelimit": "ErrCategoryRateLimit",
+	"refused":   "ErrCategoryUserRefused",
+	"risky":     "ErrCategoryContentRisky",
+}
+
+type errCode struct {
+	Code     int64
+	Name     string
+	Category string
+	Zh       string
+	En       string
+}
+
+var tmpl = template.Must(template.New("catalogue").Parse(`// Code generated by errcode_gen.go from errcode.csv; DO NOT EDIT.
+
+package wechat
+
+// errcodes documented by wechat
+const (
+{{- range .}}
+	// ErrCode{{.Name}} {{.Zh}}
+	ErrCode{{.Name}} int64 = {{.Code}}
+{{- end}}
+)
+
+// errCodeCatalogue describes the documented errcodes.
+var errCodeCatalogue = map[int64]*ErrCodeInfo{
+{{- range .}}
+	ErrCode{{.Name}}: {Code: ErrCode{{.Name}}, Name: "{{.Name}}", {{if .Category}}Category: {{.Category}}, {{end}}Zh: {{printf "%q" .Zh}}, En: {{printf "%q" .En}}},
+{{- end}}
+}
+`))
+
+func main() {
+	f, err := os.Open("errcode.csv")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		log.Fatal(err)
+	}
+	var codes []errCode
+	for i, record := range records[1:] {
+		code, err := strconv.ParseInt(record[0], 10, 64)
+		if err != nil {
+			log.Fatalf("line %d: %v", i+2, err)
+		}
+		category, ok := categories[record[2]]
+		if !ok {
+			log.Fatalf("line %d: unknown category %q", i+2, record[2])
+		}
+		codes = append(codes, errCode{
+			Code:     code,
+			Name:     record[1],
+			Category: category,
+			Zh:       record[3],
+			En:       record[4],
+		})
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, codes); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(fmt.Errorf("format: %v", err))
+	}
+	if err := ioutil.WriteFile("errcode_catalogue.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,121 @@
+package wechat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	wrap := func(ce CommonError) error {
+		return pkgerrors.Wrap(DecodeWithCommonError(OACustomMessageEndpoint, ce), "Test")
+	}
+	tests := []struct {
+		name      string
+		err       error
+		target    error
+		want      bool
+		predicate func(error) bool
+	}{
+		{
+			name:      "user refused",
+			err:       wrap(CommonError{ErrCode: 43101, ErrMsg: "user refuse to accept the msg rid: 5f8d"}),
+			target:    ErrUserRefuseToAccept,
+			want:      true,
+			predicate: IsUserRefused,
+		},
+		{
+			name:      "token expired",
+			err:       wrap(CommonError{ErrCode: 42001, ErrMsg: "access_token expired"}),
+			target:    &APIError{ErrCode: ErrCodeAccessTokenExpired, API: OACustomMessageEndpoint},
+			want:      true,
+			predicate: IsTokenExpired,
+		},
+		{
+			name:      "other api",
+			err:       wrap(CommonError{ErrCode: 87014, ErrMsg: "risky content"}),
+			target:    &APIError{ErrCode: ErrCodeRiskyContent, API: MiniProgramSecMsgEndpoint},
+			want:      false,
+			predicate: IsContentRisky,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if errors.Is(tt.err, tt.target) != tt.want {
+				t.Log(tt.err, tt.target)
+				t.FailNow()
+			}
+			if !tt.predicate(tt.err) || IsRateLimited(tt.err) {
+				t.Log("unexpected category", tt.err)
+				t.FailNow()
+			}
+			var e *APIError
+			if !errors.As(tt.err, &e) || e.API != OACustomMessageEndpoint || e.Info() == nil {
+				t.Log(e)
+				t.FailNow()
+			}
+		})
+	}
+	e, _ := AsAPIError(wrap(CommonError{ErrCode: 43101, ErrMsg: "user refuse to accept the msg rid: 5f8d"}))
+	if e.RID != "5f8d" {
+		t.Log(e.RID)
+		t.FailNow()
+	}
+}
+
+func TestError_Unwrap(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"errcode":40164,"errmsg":"invalid ip"}`)
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(OfficeAccountHost, ts.URL))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	_, err = client.OfficeAccountAccessToken().SetAppID("appid").SetSecret("secret").Do(ctx)
+	if !IsForbidden(err) || !IsErrCode(err, ErrCodeInvalidIP) {
+		t.Log(err)
+		t.FailNow()
+	}
+}
+
+func TestClient_SetCheckErrCode(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"errcode":87014,"errmsg":"risky content"}`)
+	}))
+	defer ts.Close()
+	tests := []struct {
+		name    string
+		check   bool
+		wantErr bool
+	}{
+		{name: "check", check: true, wantErr: true},
+		{name: "opt-out", check: false, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(SetHostURL(MiniProgramHost, ts.URL), SetCheckErrCode(tt.check))
+			if err != nil {
+				t.Log(err)
+				t.FailNow()
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			ret, err := client.MiniProgramSecMsg().SetAccessToken("token").SetMessage("msg").Do(ctx)
+			if IsContentRisky(err) != tt.wantErr || ret == nil || ret.ErrCode != ErrCodeRiskyContent {
+				t.Log(ret, err)
+				t.FailNow()
+			}
+		})
+	}
+}
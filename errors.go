@@ -2,9 +2,7 @@ package wechat
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 
@@ -16,12 +14,11 @@ import (
 //
 // HTTP status codes between in the range [200..299] are considered successful.
 // All other errors are considered errors except they are specified in
-// ignoreErrors. This is necessary because for some services, HTTP status 404
-// is a valid response from wechat (e.g. the Exists service).
+// ignoreErrors.
 //
-// The func tries to parse error details as returned from wechat
-// and encapsulates them in type wechat.Error.
-func checkResponse(req *http.Request, res *http.Response, ignoreErrors ...int) error {
+// The func encapsulates the status in type wechat.Error, along with the
+// *APIError if the body carries an errcode.
+func checkResponse(req *http.Request, res *http.Response, body []byte, ignoreErrors ...int) error {
 	// 200-299 are valid status codes
 	if res.StatusCode >= 200 && res.StatusCode <= 299 {
 		return nil
@@ -32,62 +29,46 @@ func checkResponse(req *http.Request, res *http.Response, ignoreErrors ...int) e
 			return nil
 		}
 	}
-	return createResponseError(res)
+	return createResponseError(req, res, body)
 }
 
 // createResponseError creates an Error structure from the HTTP response,
-// its status code and the error information sent by wechat.
-func createResponseError(res *http.Response) error {
-	if res.Body == nil {
-		return &Error{Status: res.StatusCode}
-	}
-	data, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return &Error{Status: res.StatusCode}
-	}
-	errReply := new(Error)
-	err = json.Unmarshal(data, errReply)
-	if err != nil {
-		return &Error{Status: res.StatusCode}
-	}
-	if errReply != nil {
-		if errReply.Status == 0 {
-			errReply.Status = res.StatusCode
+// its status code and the errcode sent by wechat in body.
+func createResponseError(req *http.Request, res *http.Response, body []byte) error {
+	e := &Error{Status: res.StatusCode}
+	if ce := peekCommonError(body); ce != nil {
+		api := ""
+		if req != nil && req.URL != nil {
+			api = req.URL.Path
 		}
-		return errReply
+		e.APIError = NewAPIError(api, *ce)
 	}
-	return &Error{Status: res.StatusCode}
+	return e
 }
 
-// Error encapsulates error details as returned from wechat.
+// Error is returned when wechat answers with a HTTP status other than 2xx.
 type Error struct {
-	Status  int           `json:"status"`
-	Details *ErrorDetails `json:"error,omitempty"`
-}
-
-// ErrorDetails encapsulate error details from wechat.
-// It is used in e.g. wechat.Error and wechat.BulkResponseItem.
-type ErrorDetails struct {
-	Type         string                   `json:"type"`
-	Reason       string                   `json:"reason"`
-	ResourceType string                   `json:"resource.type,omitempty"`
-	ResourceID   string                   `json:"resource.id,omitempty"`
-	Index        string                   `json:"index,omitempty"`
-	Phase        string                   `json:"phase,omitempty"`
-	Grouped      bool                     `json:"grouped,omitempty"`
-	CausedBy     map[string]interface{}   `json:"caused_by,omitempty"`
-	RootCause    []*ErrorDetails          `json:"root_cause,omitempty"`
-	FailedShards []map[string]interface{} `json:"failed_shards,omitempty"`
+	Status int
+	// APIError is set if the body carries an errcode.
+	APIError *APIError
 }
 
 // Error returns a string representation of the error.
 func (e *Error) Error() string {
-	if e.Details != nil && e.Details.Reason != "" {
-		return fmt.Sprintf("wechat: Error %d (%s): %s [type=%s]", e.Status, http.StatusText(e.Status), e.Details.Reason, e.Details.Type)
+	if e.APIError != nil {
+		return fmt.Sprintf("wechat: Error %d (%s): %v", e.Status, http.StatusText(e.Status), e.APIError)
 	}
 	return fmt.Sprintf("wechat: Error %d (%s)", e.Status, http.StatusText(e.Status))
 }
 
+// Unwrap returns the *APIError of the response, if any.
+func (e *Error) Unwrap() error {
+	if e.APIError == nil {
+		return nil
+	}
+	return e.APIError
+}
+
 // IsContextErr returns true if the error is from a context that was canceled or deadline exceeded
 func IsContextErr(err error) bool {
 	if err == context.Canceled || err == context.DeadlineExceeded {
@@ -143,7 +124,8 @@ func IsForbidden(err interface{}) bool {
 
 // IsStatusCode returns true if the given error indicates that the wechat
 // operation returned the specified HTTP status code. The err parameter can be of
-// type *http.Response, *Error, Error, or int (indicating the HTTP status code).
+// type *http.Response, *Error, Error, an error wrapping an *Error, or int
+// (indicating the HTTP status code).
 func IsStatusCode(err interface{}, code int) bool {
 	switch e := err.(type) {
 	case *http.Response:
@@ -154,6 +136,9 @@ func IsStatusCode(err interface{}, code int) bool {
 		return e.Status == code
 	case int:
 		return e == code
+	case error:
+		var se *Error
+		return errors.As(e, &se) && se.Status == code
 	}
 	return false
 }
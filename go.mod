@@ -3,6 +3,8 @@ module github.com/jony4/wechat
 go 1.15
 
 require (
+	github.com/alicebob/miniredis/v2 v2.23.0
+	github.com/go-redis/redis/v8 v8.11.4
 	github.com/patrickmn/go-cache v2.1.0+incompatible
-	github.com/pkg/errors v0.8.1
+	github.com/pkg/errors v0.9.1
 )
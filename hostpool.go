@@ -0,0 +1,207 @@
+package wechat
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// hostConn is one base url of a hostPool together with its health state.
+type hostConn struct {
+	pool *hostPool
+	url  string // base url without trailing slash, e.g. https://api2.weixin.qq.com
+
+	mu        sync.RWMutex
+	dead      bool
+	deadSince time.Time
+	failures  int
+}
+
+// URL returns the base url of the host.
+func (h *hostConn) URL() string {
+	return h.url
+}
+
+// IsDead returns true if the host is marked as dead.
+func (h *hostConn) IsDead() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.dead
+}
+
+// MarkAsDead marks the host as dead, it won't be used until a health check
+// marks it as alive again.
+func (h *hostConn) MarkAsDead() {
+	h.mu.Lock()
+	if !h.dead {
+		h.deadSince = time.Now().UTC()
+	}
+	h.dead = true
+	h.failures++
+	h.mu.Unlock()
+}
+
+// MarkAsAlive marks the host as alive.
+func (h *hostConn) MarkAsAlive() {
+	h.mu.Lock()
+	h.dead = false
+	h.failures = 0
+	h.mu.Unlock()
+}
+
+// hostPool is a list of base urls serving the same logical host, in order
+// of preference. Requests go to the first host that is alive, so traffic
+// falls back to the primary host as soon as it recovers.
+type hostPool struct {
+	name  string // logical host, e.g. OfficeAccountHost
+	hosts []*hostConn
+
+	mu     sync.Mutex
+	active *hostConn // last host returned by next
+}
+
+// newHostPool creates a pool from real hosts (api2.weixin.qq.com) or
+// base urls (http://127.0.0.1:8080).
+func newHostPool(name, scheme string, hosts []string) *hostPool {
+	p := &hostPool{name: name}
+	for _, host := range hosts {
+		baseURL := host
+		if !strings.Contains(host, "://") {
+			baseURL = scheme + "://" + host
+		}
+		p.hosts = append(p.hosts, &hostConn{
+			pool: p,
+			url:  strings.TrimRight(baseURL, "/"),
+		})
+	}
+	return p
+}
+
+// next returns the host to send the next request to. It returns ErrNoClient
+// if all hosts are dead. If resurrect is true, all hosts are marked as alive
+// in that case instead, because no health check would ever do it.
+func (p *hostPool) next(resurrect bool) (conn *hostConn, switched bool, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, h := range p.hosts {
+		if !h.IsDead() {
+			conn = h
+			break
+		}
+	}
+	if conn == nil {
+		if !resurrect || len(p.hosts) == 0 {
+			return nil, false, ErrNoClient
+		}
+		for _, h := range p.hosts {
+			h.MarkAsAlive()
+		}
+		conn = p.hosts[0]
+	}
+	switched = p.active != conn
+	p.active = conn
+	return conn, switched, nil
+}
+
+// hasAlive returns true if at least one host of the pool is alive.
+func (p *hostPool) hasAlive() bool {
+	for _, h := range p.hosts {
+		if !h.IsDead() {
+			return true
+		}
+	}
+	return false
+}
+
+// failover marks conn as dead after a connection error or a 5xx response
+// and reports whether the request can be sent to another host of the pool.
+func (c *Client) failover(conn *hostConn, reason interface{}) bool {
+	if conn == nil {
+		return false
+	}
+	conn.MarkAsDead()
+	c.errorf("wechat: %s marked as dead: %v", conn.url, reason)
+	return conn.pool.hasAlive()
+}
+
+// canFailover reports whether a request that failed with the transport
+// error err may be sent again to another host right away. That is the case
+// if the connection failed before the request was written, or if the
+// method is idempotent. Other requests, e.g. a POST whose connection broke
+// while waiting for the reply, may already be done by wechat and are left
+// to the retrier.
+func canFailover(method string, err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	switch strings.ToUpper(method) {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// healthchecker periodically probes dead hosts of all pools until stop
+// is closed.
+func (c *Client) healthchecker(stop <-chan struct{}) {
+	c.mu.RLock()
+	interval := c.healthcheckInterval
+	c.mu.RUnlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			c.healthcheck()
+		}
+	}
+}
+
+// healthcheck probes every dead host once and marks it as alive if it
+// answers with anything but a 5xx.
+func (c *Client) healthcheck() {
+	c.mu.RLock()
+	timeout := c.healthcheckTimeout
+	pools := make([]*hostPool, 0, len(c.hostPools))
+	for _, p := range c.hostPools {
+		pools = append(pools, p)
+	}
+	c.mu.RUnlock()
+
+	for _, p := range pools {
+		for _, h := range p.hosts {
+			if !h.IsDead() {
+				continue
+			}
+			if c.probe(h.url, timeout) {
+				h.MarkAsAlive()
+				c.infof("wechat: %s marked as alive", h.url)
+			}
+		}
+	}
+}
+
+// probe sends a HEAD request to baseURL.
+func (c *Client) probe(baseURL string, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodHead, baseURL+"/", nil)
+	if err != nil {
+		return false
+	}
+	res, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return false
+	}
+	res.Body.Close()
+	return res.StatusCode < http.StatusInternalServerError
+}
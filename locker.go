@@ -0,0 +1,105 @@
+package wechat
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Locker is an optional distributed lock that goes along with a shared
+// Cache. BasicAccessToken holds it while calling Credentials, so only one
+// process sharing the cache refreshes a token at a time, see SetLocker.
+type Locker interface {
+	// Lock blocks until the lock of key is acquired or ctx is done. The lock
+	// is released automatically after lease. The returned token is needed
+	// to release it.
+	Lock(ctx context.Context, key string, lease time.Duration) (token string, err error)
+	// Unlock releases the lock of key if it is still held with token.
+	Unlock(ctx context.Context, key, token string) error
+}
+
+// var of locker
+var (
+	ErrLockNotHeld = errors.New("lock not held")
+)
+
+// memLock is a lock held in a MemLocker.
+type memLock struct {
+	token    string
+	expires  time.Time
+	released chan struct{}
+}
+
+// MemLocker is an in-process Locker, e.g. for tests. It doesn't protect
+// anything across processes.
+type MemLocker struct {
+	mu    sync.Mutex
+	locks map[string]*memLock
+}
+
+// NewMemLocker NewMemLocker
+func NewMemLocker() *MemLocker {
+	return &MemLocker{
+		locks: make(map[string]*memLock),
+	}
+}
+
+// Lock Lock
+func (ml *MemLocker) Lock(ctx context.Context, key string, lease time.Duration) (string, error) {
+	for {
+		ml.mu.Lock()
+		l, ok := ml.locks[key]
+		if !ok || time.Now().After(l.expires) {
+			token, err := lockToken()
+			if err != nil {
+				ml.mu.Unlock()
+				return "", errors.Wrap(err, "MemLocker.Lock")
+			}
+			ml.locks[key] = &memLock{
+				token:    token,
+				expires:  time.Now().Add(lease),
+				released: make(chan struct{}),
+			}
+			ml.mu.Unlock()
+			return token, nil
+		}
+		released, wait := l.released, time.Until(l.expires)
+		ml.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-released:
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return "", ctx.Err()
+		}
+		timer.Stop()
+	}
+}
+
+// Unlock Unlock
+func (ml *MemLocker) Unlock(ctx context.Context, key, token string) error {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+	l, ok := ml.locks[key]
+	if !ok || l.token != token {
+		return ErrLockNotHeld
+	}
+	delete(ml.locks, key)
+	close(l.released)
+	return nil
+}
+
+// lockToken returns a random token identifying the holder of a lock.
+func lockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
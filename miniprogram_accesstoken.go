@@ -80,7 +80,7 @@ func (mpat *MiniProgramAccessToken) Do(ctx context.Context) (*MiniProgramAccessT
 	res, err := mpat.client.PerformRequest(ctx, PerformRequestOptions{
 		Method:   http.MethodGet,
 		Params:   params,
-		BaseURI:  MiniProgramBaseHost,
+		BaseURI:  MiniProgramHost,
 		Endpoint: MiniProgramAccessTokenEndpoint,
 	})
 	if err != nil {
@@ -88,8 +88,8 @@ func (mpat *MiniProgramAccessToken) Do(ctx context.Context) (*MiniProgramAccessT
 	}
 	// Return operation response
 	ret := new(MiniProgramAccessTokenResponse)
-	if err := mpat.client.decoder.Decode(res.Body, ret); err != nil {
-		return nil, errors.Wrap(err, "MiniProgramAccessToken.Do")
+	if err := mpat.client.decodeResponse(MiniProgramAccessTokenEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "MiniProgramAccessToken.Do")
 	}
 	return ret, nil
 }
@@ -100,8 +100,7 @@ func (mpat *MiniProgramAccessToken) Credentials(ctx context.Context) (*AccessTok
 	if err != nil {
 		return nil, errors.Wrap(err, "MiniProgramAccessToken.Credentials")
 	}
-	if res.ErrCode != 0 {
-		err = fmt.Errorf("errcode: %v, errmsg: %s", res.ErrCode, res.ErrMsg)
+	if err := DecodeWithCommonError(MiniProgramAccessTokenEndpoint, res.CommonError); err != nil {
 		return nil, errors.Wrap(err, "MiniProgramAccessToken.Credentials")
 	}
 	at := &AccessToken{
@@ -2,9 +2,13 @@ package wechat
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"mime"
 	"net/http"
 	"net/url"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 )
@@ -16,16 +20,60 @@ const (
 	MiniProgramAppCodeCreateEndpoint     = "cgi-bin/wxaapp/createwxaqrcode"
 )
 
-// MiniProgramAppCodeGet MiniProgramAppCodeGet
+// limits of the mini program code apis
+const (
+	MiniProgramAppCodeMinWidth    = 280
+	MiniProgramAppCodeMaxWidth    = 1280
+	MiniProgramAppCodeMaxPathLen  = 128
+	MiniProgramAppCodeMaxSceneLen = 32
+)
+
+// allowed env_version of the mini program code apis
+var allowedAppCodeEnvVersion = map[string]struct{}{
+	"release": {},
+	"trial":   {},
+	"develop": {},
+}
+
+// appCodeSceneChars are the characters allowed in a scene besides digits
+// and letters.
+const appCodeSceneChars = "!#$&'()*+,/:;=?@-._~"
+
+// MiniProgramAppCodeLineColor 线条颜色，auto_color 为 false 时生效
+type MiniProgramAppCodeLineColor struct {
+	R int `json:"r"`
+	G int `json:"g"`
+	B int `json:"b"`
+}
+
+// MiniProgramAppCodeResponse is the image returned by the mini program code
+// apis. If the errcode check is disabled with SetCheckErrCode, a failed call
+// returns no image but the CommonError.
+type MiniProgramAppCodeResponse struct {
+	CommonError
+	ContentType string // image/jpeg or image/png
+	Image       []byte
+}
+
+// -- MiniProgramAppCodeGet --
+
+// MiniProgramAppCodeGet 获取小程序码，适用于需要的码数量较少的业务场景
 type MiniProgramAppCodeGet struct {
 	client *Client
 
-	accessToken   string
-	openid        string
-	usingTransID  bool
-	transactionID string
-	mchID         string
-	outTradeNo    string
+	accessToken string
+	iat         IAccessToken
+	body        MiniProgramAppCodeGetBody
+}
+
+// MiniProgramAppCodeGetBody MiniProgramAppCodeGetBody
+type MiniProgramAppCodeGetBody struct {
+	Path       string                       `json:"path"`
+	Width      int                          `json:"width,omitempty"`
+	AutoColor  bool                         `json:"auto_color,omitempty"`
+	LineColor  *MiniProgramAppCodeLineColor `json:"line_color,omitempty"`
+	IsHyaline  bool                         `json:"is_hyaline,omitempty"`
+	EnvVersion string                       `json:"env_version,omitempty"`
 }
 
 // NewMiniProgramAppCodeGet return instance of NewMiniProgramAppCodeGet
@@ -36,113 +84,107 @@ func NewMiniProgramAppCodeGet(client *Client) *MiniProgramAppCodeGet {
 	return mpb
 }
 
-// SetOpenID SetOpenID
-func (mpb *MiniProgramAppCodeGet) SetOpenID(openid string) *MiniProgramAppCodeGet {
-	mpb.openid = openid
-	return mpb
-}
-
 // SetAccessToken SetAccessToken
 func (mpb *MiniProgramAppCodeGet) SetAccessToken(accessToken string) *MiniProgramAppCodeGet {
 	mpb.accessToken = accessToken
 	return mpb
 }
 
-// SetTransactionID SetTransactionID
-func (mpb *MiniProgramAppCodeGet) SetTransactionID(transactionID string) *MiniProgramAppCodeGet {
-	mpb.transactionID = transactionID
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (mpb *MiniProgramAppCodeGet) SetAccessTokenSource(iat IAccessToken) *MiniProgramAppCodeGet {
+	mpb.iat = iat
+	return mpb
+}
+
+// SetPath 扫码进入的小程序页面路径，可以带参数，最大长度 128 字节
+func (mpb *MiniProgramAppCodeGet) SetPath(path string) *MiniProgramAppCodeGet {
+	mpb.body.Path = path
+	return mpb
+}
+
+// SetWidth 二维码的宽度，单位 px，最小 280px，最大 1280px，默认 430px
+func (mpb *MiniProgramAppCodeGet) SetWidth(width int) *MiniProgramAppCodeGet {
+	mpb.body.Width = width
+	return mpb
+}
+
+// SetAutoColor 自动配置线条颜色
+func (mpb *MiniProgramAppCodeGet) SetAutoColor(autoColor bool) *MiniProgramAppCodeGet {
+	mpb.body.AutoColor = autoColor
 	return mpb
 }
 
-// SetMchID SetMchID
-func (mpb *MiniProgramAppCodeGet) SetMchID(mchID string) *MiniProgramAppCodeGet {
-	mpb.mchID = mchID
+// SetLineColor SetLineColor
+func (mpb *MiniProgramAppCodeGet) SetLineColor(r, g, b int) *MiniProgramAppCodeGet {
+	mpb.body.LineColor = &MiniProgramAppCodeLineColor{R: r, G: g, B: b}
 	return mpb
 }
 
-// SetOutTradeNo SetOutTradeNo
-func (mpb *MiniProgramAppCodeGet) SetOutTradeNo(outTradeNo string) *MiniProgramAppCodeGet {
-	mpb.outTradeNo = outTradeNo
+// SetIsHyaline 是否需要透明底色
+func (mpb *MiniProgramAppCodeGet) SetIsHyaline(isHyaline bool) *MiniProgramAppCodeGet {
+	mpb.body.IsHyaline = isHyaline
 	return mpb
 }
 
-// SetUsingTransID SetUsingTransID
-func (mpb *MiniProgramAppCodeGet) SetUsingTransID() *MiniProgramAppCodeGet {
-	mpb.usingTransID = true
+// SetEnvVersion 要打开的小程序版本，release、trial 或 develop
+func (mpb *MiniProgramAppCodeGet) SetEnvVersion(envVersion string) *MiniProgramAppCodeGet {
+	mpb.body.EnvVersion = envVersion
 	return mpb
 }
 
 // Validate checks if the operation is valid.
 func (mpb *MiniProgramAppCodeGet) Validate() error {
 	var invalid []string
-	if mpb.openid == "" {
-		invalid = append(invalid, "openid")
+	if mpb.body.Path == "" {
+		invalid = append(invalid, "path")
 	}
-	if mpb.accessToken == "" {
+	if mpb.accessToken == "" && mpb.iat == nil {
 		invalid = append(invalid, "access_token")
 	}
 	if len(invalid) > 0 {
 		return fmt.Errorf("missing required fields: %v", invalid)
 	}
-	if mpb.usingTransID && mpb.transactionID == "" {
-		return fmt.Errorf("transaction_id is nil")
-	}
-	if !mpb.usingTransID && (mpb.mchID == "" || mpb.outTradeNo == "") {
-		return fmt.Errorf("mch_id or out_trade_no is nil")
+	if len(mpb.body.Path) > MiniProgramAppCodeMaxPathLen {
+		return fmt.Errorf("path is longer than %d bytes", MiniProgramAppCodeMaxPathLen)
 	}
-	return nil
+	return validateAppCodeStyle(mpb.body.Width, mpb.body.LineColor, mpb.body.EnvVersion)
 }
 
 // Do Do
-func (mpb *MiniProgramAppCodeGet) Do(ctx context.Context) (*MiniProgramAppCodeGetResponse, error) {
+func (mpb *MiniProgramAppCodeGet) Do(ctx context.Context) (*MiniProgramAppCodeResponse, error) {
 	// Check pre-conditions
 	if err := mpb.Validate(); err != nil {
 		return nil, errors.Wrap(err, "MiniProgramAppCodeGet.Do")
 	}
-	// url params
-	params := url.Values{}
-	params.Set("openid", mpb.openid)
-	params.Set("access_token", mpb.accessToken)
-	if mpb.usingTransID {
-		params.Set("transaction_id", mpb.transactionID)
-	} else {
-		params.Set("mch_id", mpb.mchID)
-		params.Set("out_trade_no", mpb.outTradeNo)
-	}
-	// PerformRequest
-	res, err := mpb.client.PerformRequest(ctx, PerformRequestOptions{
-		Method:   http.MethodGet,
-		Params:   params,
-		BaseURI:  MiniProgramBaseHost,
-		Endpoint: MiniProgramAppCodeGetEndpoint,
-	})
+	ret, err := mpb.client.doAppCode(ctx, mpb.accessToken, mpb.iat, MiniProgramAppCodeGetEndpoint, &mpb.body)
 	if err != nil {
-		return nil, errors.Wrap(err, "MiniProgramAppCodeGet.Do")
-	}
-	// Return operation response
-	ret := new(MiniProgramAppCodeGetResponse)
-	if err := mpb.client.decoder.Decode(res.Body, ret); err != nil {
-		return nil, errors.Wrap(err, "MiniProgramAppCodeGet.Do")
+		return ret, errors.Wrap(err, "MiniProgramAppCodeGet.Do")
 	}
 	return ret, nil
 }
 
-// MiniProgramAppCodeGetResponse MiniProgramAppCodeGetResponse
-type MiniProgramAppCodeGetResponse struct {
-	CommonError
-	UnionID string `json:"unionid"`
-}
+// -- MiniProgramAppCodeGetUnlimit --
 
-// MiniProgramAppCodeGetUnlimit MiniProgramAppCodeGetUnlimit
+// MiniProgramAppCodeGetUnlimit 获取小程序码，适用于需要的码数量极多的业务场景
 type MiniProgramAppCodeGetUnlimit struct {
 	client *Client
 
-	accessToken   string
-	openid        string
-	usingTransID  bool
-	transactionID string
-	mchID         string
-	outTradeNo    string
+	accessToken string
+	iat         IAccessToken
+	body        MiniProgramAppCodeGetUnlimitBody
+}
+
+// MiniProgramAppCodeGetUnlimitBody MiniProgramAppCodeGetUnlimitBody
+type MiniProgramAppCodeGetUnlimitBody struct {
+	Scene      string                       `json:"scene"`
+	Page       string                       `json:"page,omitempty"`
+	CheckPath  *bool                        `json:"check_path,omitempty"`
+	EnvVersion string                       `json:"env_version,omitempty"`
+	Width      int                          `json:"width,omitempty"`
+	AutoColor  bool                         `json:"auto_color,omitempty"`
+	LineColor  *MiniProgramAppCodeLineColor `json:"line_color,omitempty"`
+	IsHyaline  bool                         `json:"is_hyaline,omitempty"`
 }
 
 // NewMiniProgramAppCodeGetUnlimit return instance of NewMiniProgramAppCodeGetUnlimit
@@ -153,113 +195,116 @@ func NewMiniProgramAppCodeGetUnlimit(client *Client) *MiniProgramAppCodeGetUnlim
 	return mpb
 }
 
-// SetOpenID SetOpenID
-func (mpb *MiniProgramAppCodeGetUnlimit) SetOpenID(openid string) *MiniProgramAppCodeGetUnlimit {
-	mpb.openid = openid
-	return mpb
-}
-
 // SetAccessToken SetAccessToken
 func (mpb *MiniProgramAppCodeGetUnlimit) SetAccessToken(accessToken string) *MiniProgramAppCodeGetUnlimit {
 	mpb.accessToken = accessToken
 	return mpb
 }
 
-// SetTransactionID SetTransactionID
-func (mpb *MiniProgramAppCodeGetUnlimit) SetTransactionID(transactionID string) *MiniProgramAppCodeGetUnlimit {
-	mpb.transactionID = transactionID
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (mpb *MiniProgramAppCodeGetUnlimit) SetAccessTokenSource(iat IAccessToken) *MiniProgramAppCodeGetUnlimit {
+	mpb.iat = iat
+	return mpb
+}
+
+// SetScene 最大 32 个可见字符，只支持数字，大小写英文以及部分特殊字符：!#$&'()*+,/:;=?@-._~
+func (mpb *MiniProgramAppCodeGetUnlimit) SetScene(scene string) *MiniProgramAppCodeGetUnlimit {
+	mpb.body.Scene = scene
+	return mpb
+}
+
+// SetPage 已经发布的小程序存在的页面，根路径前不要填加 /，不填默认跳主页面
+func (mpb *MiniProgramAppCodeGetUnlimit) SetPage(page string) *MiniProgramAppCodeGetUnlimit {
+	mpb.body.Page = page
+	return mpb
+}
+
+// SetCheckPath 检查 page 是否存在，默认 true
+func (mpb *MiniProgramAppCodeGetUnlimit) SetCheckPath(checkPath bool) *MiniProgramAppCodeGetUnlimit {
+	mpb.body.CheckPath = &checkPath
 	return mpb
 }
 
-// SetMchID SetMchID
-func (mpb *MiniProgramAppCodeGetUnlimit) SetMchID(mchID string) *MiniProgramAppCodeGetUnlimit {
-	mpb.mchID = mchID
+// SetEnvVersion 要打开的小程序版本，release、trial 或 develop
+func (mpb *MiniProgramAppCodeGetUnlimit) SetEnvVersion(envVersion string) *MiniProgramAppCodeGetUnlimit {
+	mpb.body.EnvVersion = envVersion
 	return mpb
 }
 
-// SetOutTradeNo SetOutTradeNo
-func (mpb *MiniProgramAppCodeGetUnlimit) SetOutTradeNo(outTradeNo string) *MiniProgramAppCodeGetUnlimit {
-	mpb.outTradeNo = outTradeNo
+// SetWidth 二维码的宽度，单位 px，最小 280px，最大 1280px，默认 430px
+func (mpb *MiniProgramAppCodeGetUnlimit) SetWidth(width int) *MiniProgramAppCodeGetUnlimit {
+	mpb.body.Width = width
 	return mpb
 }
 
-// SetUsingTransID SetUsingTransID
-func (mpb *MiniProgramAppCodeGetUnlimit) SetUsingTransID() *MiniProgramAppCodeGetUnlimit {
-	mpb.usingTransID = true
+// SetAutoColor 自动配置线条颜色
+func (mpb *MiniProgramAppCodeGetUnlimit) SetAutoColor(autoColor bool) *MiniProgramAppCodeGetUnlimit {
+	mpb.body.AutoColor = autoColor
+	return mpb
+}
+
+// SetLineColor SetLineColor
+func (mpb *MiniProgramAppCodeGetUnlimit) SetLineColor(r, g, b int) *MiniProgramAppCodeGetUnlimit {
+	mpb.body.LineColor = &MiniProgramAppCodeLineColor{R: r, G: g, B: b}
+	return mpb
+}
+
+// SetIsHyaline 是否需要透明底色
+func (mpb *MiniProgramAppCodeGetUnlimit) SetIsHyaline(isHyaline bool) *MiniProgramAppCodeGetUnlimit {
+	mpb.body.IsHyaline = isHyaline
 	return mpb
 }
 
 // Validate checks if the operation is valid.
 func (mpb *MiniProgramAppCodeGetUnlimit) Validate() error {
 	var invalid []string
-	if mpb.openid == "" {
-		invalid = append(invalid, "openid")
+	if mpb.body.Scene == "" {
+		invalid = append(invalid, "scene")
 	}
-	if mpb.accessToken == "" {
+	if mpb.accessToken == "" && mpb.iat == nil {
 		invalid = append(invalid, "access_token")
 	}
 	if len(invalid) > 0 {
 		return fmt.Errorf("missing required fields: %v", invalid)
 	}
-	if mpb.usingTransID && mpb.transactionID == "" {
-		return fmt.Errorf("transaction_id is nil")
+	if err := ValidateAppCodeScene(mpb.body.Scene); err != nil {
+		return err
 	}
-	if !mpb.usingTransID && (mpb.mchID == "" || mpb.outTradeNo == "") {
-		return fmt.Errorf("mch_id or out_trade_no is nil")
+	if strings.HasPrefix(mpb.body.Page, "/") {
+		return fmt.Errorf("page must not start with /")
 	}
-	return nil
+	return validateAppCodeStyle(mpb.body.Width, mpb.body.LineColor, mpb.body.EnvVersion)
 }
 
 // Do Do
-func (mpb *MiniProgramAppCodeGetUnlimit) Do(ctx context.Context) (*MiniProgramAppCodeGetUnlimitResponse, error) {
+func (mpb *MiniProgramAppCodeGetUnlimit) Do(ctx context.Context) (*MiniProgramAppCodeResponse, error) {
 	// Check pre-conditions
 	if err := mpb.Validate(); err != nil {
 		return nil, errors.Wrap(err, "MiniProgramAppCodeGetUnlimit.Do")
 	}
-	// url params
-	params := url.Values{}
-	params.Set("openid", mpb.openid)
-	params.Set("access_token", mpb.accessToken)
-	if mpb.usingTransID {
-		params.Set("transaction_id", mpb.transactionID)
-	} else {
-		params.Set("mch_id", mpb.mchID)
-		params.Set("out_trade_no", mpb.outTradeNo)
-	}
-	// PerformRequest
-	res, err := mpb.client.PerformRequest(ctx, PerformRequestOptions{
-		Method:   http.MethodGet,
-		Params:   params,
-		BaseURI:  MiniProgramBaseHost,
-		Endpoint: MiniProgramAppCodeGetUnlimitEndpoint,
-	})
+	ret, err := mpb.client.doAppCode(ctx, mpb.accessToken, mpb.iat, MiniProgramAppCodeGetUnlimitEndpoint, &mpb.body)
 	if err != nil {
-		return nil, errors.Wrap(err, "MiniProgramAppCodeGetUnlimit.Do")
-	}
-	// Return operation response
-	ret := new(MiniProgramAppCodeGetUnlimitResponse)
-	if err := mpb.client.decoder.Decode(res.Body, ret); err != nil {
-		return nil, errors.Wrap(err, "MiniProgramAppCodeGetUnlimit.Do")
+		return ret, errors.Wrap(err, "MiniProgramAppCodeGetUnlimit.Do")
 	}
 	return ret, nil
 }
 
-// MiniProgramAppCodeGetUnlimitResponse MiniProgramAppCodeGetUnlimitResponse
-type MiniProgramAppCodeGetUnlimitResponse struct {
-	CommonError
-	UnionID string `json:"unionid"`
-}
+// -- MiniProgramAppCodeCreate --
 
-// MiniProgramAppCodeCreate MiniProgramAppCodeCreate
+// MiniProgramAppCodeCreate 获取小程序二维码，适用于需要的码数量较少的业务场景
 type MiniProgramAppCodeCreate struct {
 	client *Client
 
-	accessToken   string
-	openid        string
-	usingTransID  bool
-	transactionID string
-	mchID         string
-	outTradeNo    string
+	accessToken string
+	iat         IAccessToken
+	body        MiniProgramAppCodeCreateBody
+}
+
+// MiniProgramAppCodeCreateBody MiniProgramAppCodeCreateBody
+type MiniProgramAppCodeCreateBody struct {
+	Path  string `json:"path"`
+	Width int    `json:"width,omitempty"`
 }
 
 // NewMiniProgramAppCodeCreate return instance of NewMiniProgramAppCodeCreate
@@ -270,99 +315,143 @@ func NewMiniProgramAppCodeCreate(client *Client) *MiniProgramAppCodeCreate {
 	return mpb
 }
 
-// SetOpenID SetOpenID
-func (mpb *MiniProgramAppCodeCreate) SetOpenID(openid string) *MiniProgramAppCodeCreate {
-	mpb.openid = openid
-	return mpb
-}
-
 // SetAccessToken SetAccessToken
 func (mpb *MiniProgramAppCodeCreate) SetAccessToken(accessToken string) *MiniProgramAppCodeCreate {
 	mpb.accessToken = accessToken
 	return mpb
 }
 
-// SetTransactionID SetTransactionID
-func (mpb *MiniProgramAppCodeCreate) SetTransactionID(transactionID string) *MiniProgramAppCodeCreate {
-	mpb.transactionID = transactionID
-	return mpb
-}
-
-// SetMchID SetMchID
-func (mpb *MiniProgramAppCodeCreate) SetMchID(mchID string) *MiniProgramAppCodeCreate {
-	mpb.mchID = mchID
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (mpb *MiniProgramAppCodeCreate) SetAccessTokenSource(iat IAccessToken) *MiniProgramAppCodeCreate {
+	mpb.iat = iat
 	return mpb
 }
 
-// SetOutTradeNo SetOutTradeNo
-func (mpb *MiniProgramAppCodeCreate) SetOutTradeNo(outTradeNo string) *MiniProgramAppCodeCreate {
-	mpb.outTradeNo = outTradeNo
+// SetPath 扫码进入的小程序页面路径，最大长度 128 字节
+func (mpb *MiniProgramAppCodeCreate) SetPath(path string) *MiniProgramAppCodeCreate {
+	mpb.body.Path = path
 	return mpb
 }
 
-// SetUsingTransID SetUsingTransID
-func (mpb *MiniProgramAppCodeCreate) SetUsingTransID() *MiniProgramAppCodeCreate {
-	mpb.usingTransID = true
+// SetWidth 二维码的宽度，单位 px，最小 280px，最大 1280px，默认 430px
+func (mpb *MiniProgramAppCodeCreate) SetWidth(width int) *MiniProgramAppCodeCreate {
+	mpb.body.Width = width
 	return mpb
 }
 
 // Validate checks if the operation is valid.
 func (mpb *MiniProgramAppCodeCreate) Validate() error {
 	var invalid []string
-	if mpb.openid == "" {
-		invalid = append(invalid, "openid")
+	if mpb.body.Path == "" {
+		invalid = append(invalid, "path")
 	}
-	if mpb.accessToken == "" {
+	if mpb.accessToken == "" && mpb.iat == nil {
 		invalid = append(invalid, "access_token")
 	}
 	if len(invalid) > 0 {
 		return fmt.Errorf("missing required fields: %v", invalid)
 	}
-	if mpb.usingTransID && mpb.transactionID == "" {
-		return fmt.Errorf("transaction_id is nil")
-	}
-	if !mpb.usingTransID && (mpb.mchID == "" || mpb.outTradeNo == "") {
-		return fmt.Errorf("mch_id or out_trade_no is nil")
+	if len(mpb.body.Path) > MiniProgramAppCodeMaxPathLen {
+		return fmt.Errorf("path is longer than %d bytes", MiniProgramAppCodeMaxPathLen)
 	}
-	return nil
+	return validateAppCodeStyle(mpb.body.Width, nil, "")
 }
 
 // Do Do
-func (mpb *MiniProgramAppCodeCreate) Do(ctx context.Context) (*MiniProgramAppCodeCreateResponse, error) {
+func (mpb *MiniProgramAppCodeCreate) Do(ctx context.Context) (*MiniProgramAppCodeResponse, error) {
 	// Check pre-conditions
 	if err := mpb.Validate(); err != nil {
 		return nil, errors.Wrap(err, "MiniProgramAppCodeCreate.Do")
 	}
-	// url params
-	params := url.Values{}
-	params.Set("openid", mpb.openid)
-	params.Set("access_token", mpb.accessToken)
-	if mpb.usingTransID {
-		params.Set("transaction_id", mpb.transactionID)
-	} else {
-		params.Set("mch_id", mpb.mchID)
-		params.Set("out_trade_no", mpb.outTradeNo)
+	ret, err := mpb.client.doAppCode(ctx, mpb.accessToken, mpb.iat, MiniProgramAppCodeCreateEndpoint, &mpb.body)
+	if err != nil {
+		return ret, errors.Wrap(err, "MiniProgramAppCodeCreate.Do")
+	}
+	return ret, nil
+}
+
+// -- helpers --
+
+// ValidateAppCodeScene checks the scene of MiniProgramAppCodeGetUnlimit: at
+// most 32 characters of digits, letters and !#$&'()*+,/:;=?@-._~
+func ValidateAppCodeScene(scene string) error {
+	if n := utf8.RuneCountInString(scene); n > MiniProgramAppCodeMaxSceneLen {
+		return fmt.Errorf("scene has %d characters, at most %d are allowed", n, MiniProgramAppCodeMaxSceneLen)
+	}
+	for _, r := range scene {
+		switch {
+		case r >= '0' && r <= '9', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case strings.ContainsRune(appCodeSceneChars, r):
+		default:
+			return fmt.Errorf("scene contains invalid character %q", r)
+		}
+	}
+	return nil
+}
+
+// validateAppCodeStyle checks the optional width, line color and env
+// version of a mini program code.
+func validateAppCodeStyle(width int, lineColor *MiniProgramAppCodeLineColor, envVersion string) error {
+	if width != 0 && (width < MiniProgramAppCodeMinWidth || width > MiniProgramAppCodeMaxWidth) {
+		return fmt.Errorf("width must be between %d and %d", MiniProgramAppCodeMinWidth, MiniProgramAppCodeMaxWidth)
+	}
+	if lineColor != nil {
+		for _, v := range []int{lineColor.R, lineColor.G, lineColor.B} {
+			if v < 0 || v > 255 {
+				return fmt.Errorf("line_color must be between 0 and 255")
+			}
+		}
+	}
+	if _, ok := allowedAppCodeEnvVersion[envVersion]; envVersion != "" && !ok {
+		return fmt.Errorf("not allowed env_version %q", envVersion)
+	}
+	return nil
+}
+
+// doAppCode posts body to a mini program code api and returns the image.
+func (c *Client) doAppCode(ctx context.Context, accessToken string, iat IAccessToken, endpoint string, body interface{}) (*MiniProgramAppCodeResponse, error) {
+	bodybyte, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
 	// PerformRequest
-	res, err := mpb.client.PerformRequest(ctx, PerformRequestOptions{
-		Method:   http.MethodGet,
-		Params:   params,
-		BaseURI:  MiniProgramBaseHost,
-		Endpoint: MiniProgramAppCodeCreateEndpoint,
+	res, err := c.performTokenRequest(ctx, accessToken, iat, PerformRequestOptions{
+		Method:      http.MethodPost,
+		Params:      url.Values{},
+		Body:        string(bodybyte),
+		ContentType: "application/json",
+		BaseURI:     MiniProgramHost,
+		Endpoint:    endpoint,
 	})
 	if err != nil {
-		return nil, errors.Wrap(err, "MiniProgramAppCodeCreate.Do")
+		return nil, err
 	}
-	// Return operation response
-	ret := new(MiniProgramAppCodeCreateResponse)
-	if err := mpb.client.decoder.Decode(res.Body, ret); err != nil {
-		return nil, errors.Wrap(err, "MiniProgramAppCodeCreate.Do")
+	return c.decodeAppCode(endpoint, res)
+}
+
+// decodeAppCode returns the image in res, or the error wechat answered with
+// as JSON instead. The content type is sniffed if the header doesn't name an
+// image type.
+func (c *Client) decodeAppCode(endpoint string, res *Response) (*MiniProgramAppCodeResponse, error) {
+	ret := new(MiniProgramAppCodeResponse)
+	if len(res.Body) > 0 && res.Body[0] == '{' {
+		if err := c.decodeResponse(endpoint, res, ret); err != nil {
+			return ret, err
+		}
+		if ret.ErrCode == 0 {
+			return ret, errors.Errorf("unexpected JSON response without image: %s", res.Body)
+		}
+		return ret, nil
+	}
+	contentType := res.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); !strings.HasPrefix(mediaType, "image/") {
+		contentType = http.DetectContentType(res.Body)
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, errors.Errorf("unexpected content type %q", contentType)
 	}
+	ret.ContentType = contentType
+	ret.Image = res.Body
 	return ret, nil
 }
-
-// MiniProgramAppCodeCreateResponse MiniProgramAppCodeCreateResponse
-type MiniProgramAppCodeCreateResponse struct {
-	CommonError
-	UnionID string `json:"unionid"`
-}
@@ -0,0 +1,83 @@
+package wechat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestValidateAppCodeScene(t *testing.T) {
+	tests := []struct {
+		name    string
+		scene   string
+		wantErr bool
+	}{
+		{name: "valid", scene: "id=42&from=share_~!*", wantErr: false},
+		{name: "too long", scene: "0123456789012345678901234567890123", wantErr: true},
+		{name: "invalid char", scene: "id=42 %", wantErr: true},
+		{name: "not ascii", scene: "场景", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateAppCodeScene(tt.scene); (err != nil) != tt.wantErr {
+				t.Log(err)
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestMiniProgramAppCodeGetUnlimit_Do(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := new(MiniProgramAppCodeGetUnlimitBody)
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(body) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if body.Page != "pages/index" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"errcode":41030,"errmsg":"invalid page"}`))
+			return
+		}
+		// wechat doesn't always name the image type
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(png)
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(MiniProgramHost, ts.URL))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	tests := []struct {
+		name    string
+		page    string
+		wantErr bool
+	}{
+		{name: "image", page: "pages/index", wantErr: false},
+		{name: "error", page: "pages/none", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			ret, err := client.MiniProgramAppCodeGetUnlimit().SetAccessToken("token").SetScene("id=42").SetPage(tt.page).Do(ctx)
+			if tt.wantErr {
+				if !IsErrCode(err, 41030) {
+					t.Log(ret, err)
+					t.FailNow()
+				}
+				return
+			}
+			if err != nil || ret.ContentType != "image/png" || !bytes.Equal(ret.Image, png) {
+				t.Log(ret, err)
+				t.FailNow()
+			}
+		})
+	}
+}
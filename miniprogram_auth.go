@@ -91,7 +91,7 @@ func (mpa *MiniProgramAuth) Do(ctx context.Context) (*MiniProgramAuthResponse, e
 	res, err := mpa.client.PerformRequest(ctx, PerformRequestOptions{
 		Method:   http.MethodGet,
 		Params:   params,
-		BaseURI:  MiniProgramBaseHost,
+		BaseURI:  MiniProgramHost,
 		Endpoint: MiniProgramAuthEndpoint,
 	})
 	if err != nil {
@@ -99,11 +99,8 @@ func (mpa *MiniProgramAuth) Do(ctx context.Context) (*MiniProgramAuthResponse, e
 	}
 	// Return operation response
 	ret := new(MiniProgramAuthResponse)
-	if err := mpa.client.decoder.Decode(res.Body, ret); err != nil {
-		return nil, err
-	}
-	if err := DecodeWithCommonError(fmt.Sprintf("miniprogram: %s", MiniProgramAuthEndpoint), ret.CommonError); err != nil {
-		return nil, errors.Wrap(err, "MiniProgramAuth.Do")
+	if err := mpa.client.decodeResponse(MiniProgramAuthEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "MiniProgramAuth.Do")
 	}
 	return ret, nil
 }
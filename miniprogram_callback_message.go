@@ -0,0 +1,216 @@
+package wechat
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strconv"
+)
+
+// message types and events of mini program push
+const (
+	MsgTypeMiniProgramPage    = "miniprogrampage"
+	EventUserEnterTempSession = "user_enter_tempsession"
+	EventSubscribeMsgPopup    = "subscribe_msg_popup_event"
+	EventSubscribeMsgChange   = "subscribe_msg_change_event"
+	EventSubscribeMsgSent     = "subscribe_msg_sent_event"
+	EventWxaMediaCheck        = "wxa_media_check"
+)
+
+// MiniProgramMessage is a message or event pushed to a mini program, one
+// of the MiniProgram*Message and MiniProgram*Event types, or
+// *MiniProgramUnknownMessage.
+type MiniProgramMessage interface {
+	MessageHeader() *CallbackMessageHeader
+}
+
+// -- customer service messages --
+
+// MiniProgramTextMessage 客服消息：文本
+type MiniProgramTextMessage struct {
+	CallbackMessageHeader
+	MsgID   int64  `xml:"MsgId" json:"MsgId"`
+	Content string `xml:"Content" json:"Content"`
+}
+
+// MiniProgramImageMessage 客服消息：图片
+type MiniProgramImageMessage struct {
+	CallbackMessageHeader
+	MsgID   int64  `xml:"MsgId" json:"MsgId"`
+	PicURL  string `xml:"PicUrl" json:"PicUrl"`
+	MediaID string `xml:"MediaId" json:"MediaId"`
+}
+
+// MiniProgramPageMessage 客服消息：小程序卡片
+type MiniProgramPageMessage struct {
+	CallbackMessageHeader
+	MsgID        int64  `xml:"MsgId" json:"MsgId"`
+	Title        string `xml:"Title" json:"Title"`
+	AppID        string `xml:"AppId" json:"AppId"`
+	PagePath     string `xml:"PagePath" json:"PagePath"`
+	ThumbURL     string `xml:"ThumbUrl" json:"ThumbUrl"`
+	ThumbMediaID string `xml:"ThumbMediaId" json:"ThumbMediaId"`
+}
+
+// MiniProgramUserEnterTempSessionEvent 用户进入客服会话
+type MiniProgramUserEnterTempSessionEvent struct {
+	CallbackMessageHeader
+	SessionFrom string `xml:"SessionFrom" json:"SessionFrom"`
+}
+
+// -- subscribe message events --
+
+// MiniProgramSubscribeMsgItem is an item of the subscribe message events,
+// each event sets a part of the fields.
+type MiniProgramSubscribeMsgItem struct {
+	TemplateID            string      `xml:"TemplateId" json:"TemplateId"`
+	SubscribeStatusString string      `xml:"SubscribeStatusString" json:"SubscribeStatusString,omitempty"` // accept 或 reject
+	PopupScene            string      `xml:"PopupScene" json:"PopupScene,omitempty"`
+	MsgID                 string      `xml:"MsgID" json:"MsgID,omitempty"`
+	ErrorCode             json.Number `xml:"ErrorCode" json:"ErrorCode,omitempty"`
+	ErrorStatus           string      `xml:"ErrorStatus" json:"ErrorStatus,omitempty"`
+}
+
+// MiniProgramSubscribeMsgList is a list of MiniProgramSubscribeMsgItem. In
+// JSON, wechat sends a single item as object instead of array.
+type MiniProgramSubscribeMsgList []MiniProgramSubscribeMsgItem
+
+// UnmarshalJSON UnmarshalJSON
+func (l *MiniProgramSubscribeMsgList) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '{' {
+		var item MiniProgramSubscribeMsgItem
+		if err := json.Unmarshal(b, &item); err != nil {
+			return err
+		}
+		*l = MiniProgramSubscribeMsgList{item}
+		return nil
+	}
+	var items []MiniProgramSubscribeMsgItem
+	if err := json.Unmarshal(b, &items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+// MiniProgramSubscribeMsgPopupEvent 用户在订阅消息弹窗中操作
+type MiniProgramSubscribeMsgPopupEvent struct {
+	CallbackMessageHeader
+	List MiniProgramSubscribeMsgList `xml:"SubscribeMsgPopupEvent>List" json:"List"`
+}
+
+// MiniProgramSubscribeMsgChangeEvent 用户在设置中改变订阅消息状态
+type MiniProgramSubscribeMsgChangeEvent struct {
+	CallbackMessageHeader
+	List MiniProgramSubscribeMsgList `xml:"SubscribeMsgChangeEvent>List" json:"List"`
+}
+
+// MiniProgramSubscribeMsgSentEvent 订阅消息发送结果
+type MiniProgramSubscribeMsgSentEvent struct {
+	CallbackMessageHeader
+	List MiniProgramSubscribeMsgList `xml:"SubscribeMsgSentEvent>List" json:"List"`
+}
+
+// -- media check --
+
+// MiniProgramMediaCheckDetail MiniProgramMediaCheckDetail
+type MiniProgramMediaCheckDetail struct {
+	Strategy string `xml:"strategy" json:"strategy"`
+	ErrCode  int64  `xml:"errcode" json:"errcode"`
+	Suggest  string `xml:"suggest" json:"suggest"`
+	Label    int64  `xml:"label" json:"label"`
+	Prob     int64  `xml:"prob" json:"prob"`
+}
+
+// MiniProgramMediaCheckResult MiniProgramMediaCheckResult
+type MiniProgramMediaCheckResult struct {
+	Suggest string `xml:"suggest" json:"suggest"` // risky、pass 或 review
+	Label   int64  `xml:"label" json:"label"`
+}
+
+// MiniProgramMediaCheckEvent 音视频内容安全异步检测结果，Version 2 的结果在
+// Result 和 Detail 中，Version 1 的在 IsRisky 中
+type MiniProgramMediaCheckEvent struct {
+	CallbackMessageHeader
+	AppID         string                        `xml:"appid" json:"appid"`
+	TraceID       string                        `xml:"trace_id" json:"trace_id"`
+	Version       int64                         `xml:"version" json:"version"`
+	Detail        []MiniProgramMediaCheckDetail `xml:"detail" json:"detail"`
+	ErrCode       int64                         `xml:"errcode" json:"errcode"`
+	ErrMsg        string                        `xml:"errmsg" json:"errmsg"`
+	Result        MiniProgramMediaCheckResult   `xml:"result" json:"result"`
+	IsRisky       int64                         `xml:"isrisky" json:"isrisky"`
+	ExtraInfoJSON string                        `xml:"extra_info_json" json:"extra_info_json"`
+	StatusCode    int64                         `xml:"status_code" json:"status_code"`
+}
+
+// MiniProgramUnknownMessage is a message or event without a type in this
+// package, Raw is the decrypted JSON or XML.
+type MiniProgramUnknownMessage struct {
+	CallbackMessageHeader
+	Raw []byte `xml:"-" json:"-"`
+}
+
+// miniProgramMessageTypes returns new messages by route, see
+// callbackRoute.
+var miniProgramMessageTypes = map[string]func() MiniProgramMessage{
+	MsgTypeText:            func() MiniProgramMessage { return new(MiniProgramTextMessage) },
+	MsgTypeImage:           func() MiniProgramMessage { return new(MiniProgramImageMessage) },
+	MsgTypeMiniProgramPage: func() MiniProgramMessage { return new(MiniProgramPageMessage) },
+
+	callbackRoute(MsgTypeEvent, EventUserEnterTempSession): func() MiniProgramMessage { return new(MiniProgramUserEnterTempSessionEvent) },
+	callbackRoute(MsgTypeEvent, EventSubscribeMsgPopup):    func() MiniProgramMessage { return new(MiniProgramSubscribeMsgPopupEvent) },
+	callbackRoute(MsgTypeEvent, EventSubscribeMsgChange):   func() MiniProgramMessage { return new(MiniProgramSubscribeMsgChangeEvent) },
+	callbackRoute(MsgTypeEvent, EventSubscribeMsgSent):     func() MiniProgramMessage { return new(MiniProgramSubscribeMsgSentEvent) },
+	callbackRoute(MsgTypeEvent, EventWxaMediaCheck):        func() MiniProgramMessage { return new(MiniProgramMediaCheckEvent) },
+}
+
+// DecodeMiniProgramMessage decodes the plaintext JSON or XML of a message
+// or event into its type.
+func DecodeMiniProgramMessage(raw []byte) (MiniProgramMessage, error) {
+	unmarshal := xml.Unmarshal
+	if isJSONMessage(raw) {
+		unmarshal = json.Unmarshal
+		var err error
+		if raw, err = normalizeCreateTime(raw); err != nil {
+			return nil, err
+		}
+	}
+	header := new(CallbackMessageHeader)
+	if err := unmarshal(raw, header); err != nil {
+		return nil, err
+	}
+	newMessage, ok := miniProgramMessageTypes[callbackRoute(header.MsgType, header.Event)]
+	if !ok {
+		return &MiniProgramUnknownMessage{CallbackMessageHeader: *header, Raw: raw}, nil
+	}
+	msg := newMessage()
+	if err := unmarshal(raw, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// normalizeCreateTime replaces a CreateTime sent as JSON string, like some
+// events do, by a number.
+func normalizeCreateTime(raw []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	createTime, ok := fields["CreateTime"]
+	if !ok || len(createTime) == 0 || createTime[0] != '"' {
+		return raw, nil
+	}
+	s, err := strconv.Unquote(string(createTime))
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	fields["CreateTime"] = json.RawMessage(strconv.FormatInt(n, 10))
+	return json.Marshal(fields)
+}
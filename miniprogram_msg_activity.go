@@ -21,6 +21,7 @@ type MiniProgramActivityMessageCreate struct {
 	client *Client
 
 	accessToken string
+	iat         IAccessToken
 }
 
 // NewMiniProgramActivityMessageCreate return instance of mini program auth
@@ -37,10 +38,17 @@ func (mpam *MiniProgramActivityMessageCreate) SetAccessToken(accessToken string)
 	return mpam
 }
 
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (mpam *MiniProgramActivityMessageCreate) SetAccessTokenSource(iat IAccessToken) *MiniProgramActivityMessageCreate {
+	mpam.iat = iat
+	return mpam
+}
+
 // Validate checks if the operation is valid.
 func (mpam *MiniProgramActivityMessageCreate) Validate() error {
 	var invalid []string
-	if mpam.accessToken == "" {
+	if mpam.accessToken == "" && mpam.iat == nil {
 		invalid = append(invalid, "AccessToken")
 	}
 	if len(invalid) > 0 {
@@ -57,12 +65,11 @@ func (mpam *MiniProgramActivityMessageCreate) Do(ctx context.Context) (*MiniProg
 	}
 	// url params
 	params := url.Values{}
-	params.Set("access_token", mpam.accessToken)
 	// PerformRequest
-	res, err := mpam.client.PerformRequest(ctx, PerformRequestOptions{
+	res, err := mpam.client.performTokenRequest(ctx, mpam.accessToken, mpam.iat, PerformRequestOptions{
 		Method:   http.MethodGet,
 		Params:   params,
-		BaseURI:  MiniProgramBaseHost,
+		BaseURI:  MiniProgramHost,
 		Endpoint: MiniProgramActivityMessageCreateEndpoint,
 	})
 	if err != nil {
@@ -70,8 +77,8 @@ func (mpam *MiniProgramActivityMessageCreate) Do(ctx context.Context) (*MiniProg
 	}
 	// Return operation response
 	ret := new(MiniProgramActivityMessageCreateResponse)
-	if err := mpam.client.decoder.Decode(res.Body, ret); err != nil {
-		return nil, errors.Wrap(err, "MiniProgramActivityMessageCreate.Do")
+	if err := mpam.client.decodeResponse(MiniProgramActivityMessageCreateEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "MiniProgramActivityMessageCreate.Do")
 	}
 	return ret, nil
 }
@@ -88,6 +95,7 @@ type MiniProgramActivityMessageUpdate struct {
 	client *Client
 
 	accessToken string
+	iat         IAccessToken
 	body        *MiniProgramActivityMessageUpdateBody
 }
 
@@ -165,6 +173,13 @@ func (mpamu *MiniProgramActivityMessageUpdate) SetAccessToken(accessToken string
 	return mpamu
 }
 
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (mpamu *MiniProgramActivityMessageUpdate) SetAccessTokenSource(iat IAccessToken) *MiniProgramActivityMessageUpdate {
+	mpamu.iat = iat
+	return mpamu
+}
+
 // SetBody SetBody
 func (mpamu *MiniProgramActivityMessageUpdate) SetBody(body *MiniProgramActivityMessageUpdateBody) *MiniProgramActivityMessageUpdate {
 	mpamu.body = body
@@ -180,7 +195,7 @@ func (mpamu *MiniProgramActivityMessageUpdate) Validate() error {
 	if err := mpamu.body.Validate(); err != nil {
 		invalid = append(invalid, err.Error())
 	}
-	if mpamu.accessToken == "" {
+	if mpamu.accessToken == "" && mpamu.iat == nil {
 		invalid = append(invalid, "AccessToken")
 	}
 	if len(invalid) > 0 {
@@ -201,13 +216,12 @@ func (mpamu *MiniProgramActivityMessageUpdate) Do(ctx context.Context) (*MiniPro
 	}
 	// url params
 	params := url.Values{}
-	params.Set("access_token", mpamu.accessToken)
 	// PerformRequest
-	res, err := mpamu.client.PerformRequest(ctx, PerformRequestOptions{
+	res, err := mpamu.client.performTokenRequest(ctx, mpamu.accessToken, mpamu.iat, PerformRequestOptions{
 		Method:   http.MethodPost,
 		Params:   params,
 		Body:     string(bodybyte),
-		BaseURI:  MiniProgramBaseHost,
+		BaseURI:  MiniProgramHost,
 		Endpoint: MiniProgramActivityMessageUpdateEndpoint,
 	})
 	if err != nil {
@@ -215,8 +229,8 @@ func (mpamu *MiniProgramActivityMessageUpdate) Do(ctx context.Context) (*MiniPro
 	}
 	// Return operation response
 	ret := new(MiniProgramActivityMessageUpdateResponse)
-	if err := mpamu.client.decoder.Decode(res.Body, ret); err != nil {
-		return nil, errors.Wrap(err, "MiniProgramActivityMessageUpdate.Do")
+	if err := mpamu.client.decodeResponse(MiniProgramActivityMessageUpdateEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "MiniProgramActivityMessageUpdate.Do")
 	}
 	return ret, nil
 }
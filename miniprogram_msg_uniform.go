@@ -71,7 +71,7 @@ func (mpum *MiniProgramUniformMessage) Validate() error {
 
 // BaseURI BaseURI
 func (mpum *MiniProgramUniformMessage) BaseURI() string {
-	return MiniProgramBaseHost
+	return MiniProgramHost
 }
 
 // Endpoint Endpoint
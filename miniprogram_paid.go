@@ -19,6 +19,7 @@ type MiniProgramPaid struct {
 	client *Client
 
 	accessToken   string
+	iat           IAccessToken
 	openid        string
 	usingTransID  bool
 	transactionID string
@@ -46,6 +47,13 @@ func (mpb *MiniProgramPaid) SetAccessToken(accessToken string) *MiniProgramPaid
 	return mpb
 }
 
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (mpb *MiniProgramPaid) SetAccessTokenSource(iat IAccessToken) *MiniProgramPaid {
+	mpb.iat = iat
+	return mpb
+}
+
 // SetTransactionID SetTransactionID
 func (mpb *MiniProgramPaid) SetTransactionID(transactionID string) *MiniProgramPaid {
 	mpb.transactionID = transactionID
@@ -76,7 +84,7 @@ func (mpb *MiniProgramPaid) Validate() error {
 	if mpb.openid == "" {
 		invalid = append(invalid, "openid")
 	}
-	if mpb.accessToken == "" {
+	if mpb.accessToken == "" && mpb.iat == nil {
 		invalid = append(invalid, "access_token")
 	}
 	if len(invalid) > 0 {
@@ -100,7 +108,6 @@ func (mpb *MiniProgramPaid) Do(ctx context.Context) (*MiniProgramPaidResponse, e
 	// url params
 	params := url.Values{}
 	params.Set("openid", mpb.openid)
-	params.Set("access_token", mpb.accessToken)
 	if mpb.usingTransID {
 		params.Set("transaction_id", mpb.transactionID)
 	} else {
@@ -108,10 +115,10 @@ func (mpb *MiniProgramPaid) Do(ctx context.Context) (*MiniProgramPaidResponse, e
 		params.Set("out_trade_no", mpb.outTradeNo)
 	}
 	// PerformRequest
-	res, err := mpb.client.PerformRequest(ctx, PerformRequestOptions{
+	res, err := mpb.client.performTokenRequest(ctx, mpb.accessToken, mpb.iat, PerformRequestOptions{
 		Method:   http.MethodGet,
 		Params:   params,
-		BaseURI:  MiniProgramBaseHost,
+		BaseURI:  MiniProgramHost,
 		Endpoint: MiniProgramPaidEndpoint,
 	})
 	if err != nil {
@@ -119,8 +126,8 @@ func (mpb *MiniProgramPaid) Do(ctx context.Context) (*MiniProgramPaidResponse, e
 	}
 	// Return operation response
 	ret := new(MiniProgramPaidResponse)
-	if err := mpb.client.decoder.Decode(res.Body, ret); err != nil {
-		return nil, errors.Wrap(err, "MiniProgramPaid.Do")
+	if err := mpb.client.decodeResponse(MiniProgramPaidEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "MiniProgramPaid.Do")
 	}
 	return ret, nil
 }
@@ -23,6 +23,7 @@ type MiniProgramSecImg struct {
 	client *Client
 
 	accessToken string
+	iat         IAccessToken
 	media       []byte
 }
 
@@ -40,6 +41,13 @@ func (mpb *MiniProgramSecImg) SetAccessToken(accessToken string) *MiniProgramSec
 	return mpb
 }
 
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (mpb *MiniProgramSecImg) SetAccessTokenSource(iat IAccessToken) *MiniProgramSecImg {
+	mpb.iat = iat
+	return mpb
+}
+
 // SetMedia SetMedia
 func (mpb *MiniProgramSecImg) SetMedia(media []byte) *MiniProgramSecImg {
 	mpb.media = media
@@ -49,7 +57,7 @@ func (mpb *MiniProgramSecImg) SetMedia(media []byte) *MiniProgramSecImg {
 // Validate checks if the operation is valid.
 func (mpb *MiniProgramSecImg) Validate() error {
 	var invalid []string
-	if mpb.accessToken == "" {
+	if mpb.accessToken == "" && mpb.iat == nil {
 		invalid = append(invalid, "access_token")
 	}
 	if len(mpb.media) > maxMediaSize || len(mpb.media) == 0 {
@@ -68,15 +76,14 @@ func (mpb *MiniProgramSecImg) Do(ctx context.Context) (*MiniProgramSecImgRespons
 		return nil, errors.Wrap(err, "MiniProgramSecImg.Do")
 	}
 	params := url.Values{}
-	params.Set("access_token", mpb.accessToken)
 
-	res, err := mpb.client.PerformFormRequest(ctx, PerformRequestOptions{
+	res, err := mpb.client.performTokenFormRequest(ctx, mpb.accessToken, mpb.iat, PerformRequestOptions{
 		Method:        http.MethodPost,
 		Params:        params,
 		FormValue:     mpb.media,
 		FormFieldName: "media",
 		FormFileName:  "file",
-		BaseURI:       MiniProgramBaseHost,
+		BaseURI:       MiniProgramHost,
 		Endpoint:      MiniProgramSecImgEndpoint,
 	})
 	if err != nil {
@@ -84,8 +91,8 @@ func (mpb *MiniProgramSecImg) Do(ctx context.Context) (*MiniProgramSecImgRespons
 	}
 	// Return operation response
 	ret := new(MiniProgramSecImgResponse)
-	if err := mpb.client.decoder.Decode(res.Body, ret); err != nil {
-		return nil, errors.Wrap(err, "MiniProgramSecImg.Do")
+	if err := mpb.client.decodeResponse(MiniProgramSecImgEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "MiniProgramSecImg.Do")
 	}
 	return ret, nil
 }
@@ -20,6 +20,7 @@ type MiniProgramSecMsg struct {
 	client *Client
 
 	accessToken string
+	iat         IAccessToken
 	message     string
 }
 
@@ -37,6 +38,13 @@ func (mpb *MiniProgramSecMsg) SetAccessToken(accessToken string) *MiniProgramSec
 	return mpb
 }
 
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (mpb *MiniProgramSecMsg) SetAccessTokenSource(iat IAccessToken) *MiniProgramSecMsg {
+	mpb.iat = iat
+	return mpb
+}
+
 // SetMessage SetMessage
 func (mpb *MiniProgramSecMsg) SetMessage(message string) *MiniProgramSecMsg {
 	mpb.message = message
@@ -46,7 +54,7 @@ func (mpb *MiniProgramSecMsg) SetMessage(message string) *MiniProgramSecMsg {
 // Validate checks if the operation is valid.
 func (mpb *MiniProgramSecMsg) Validate() error {
 	var invalid []string
-	if mpb.accessToken == "" {
+	if mpb.accessToken == "" && mpb.iat == nil {
 		invalid = append(invalid, "access_token")
 	}
 	if len(mpb.message) == 0 {
@@ -65,18 +73,17 @@ func (mpb *MiniProgramSecMsg) Do(ctx context.Context) (*MiniProgramSecMsgRespons
 		return nil, errors.Wrap(err, "MiniProgramSecMsg.Do")
 	}
 	params := url.Values{}
-	params.Set("access_token", mpb.accessToken)
 	bodybyte, err := json.Marshal(map[string]string{
 		"content": mpb.message,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "MiniProgramSecMsg.Do")
 	}
-	res, err := mpb.client.PerformRequest(ctx, PerformRequestOptions{
+	res, err := mpb.client.performTokenRequest(ctx, mpb.accessToken, mpb.iat, PerformRequestOptions{
 		Method:   http.MethodPost,
 		Params:   params,
 		Body:     string(bodybyte),
-		BaseURI:  MiniProgramBaseHost,
+		BaseURI:  MiniProgramHost,
 		Endpoint: MiniProgramSecMsgEndpoint,
 	})
 	if err != nil {
@@ -84,8 +91,8 @@ func (mpb *MiniProgramSecMsg) Do(ctx context.Context) (*MiniProgramSecMsgRespons
 	}
 	// Return operation response
 	ret := new(MiniProgramSecMsgResponse)
-	if err := mpb.client.decoder.Decode(res.Body, ret); err != nil {
-		return nil, errors.Wrap(err, "MiniProgramSecMsg.Do")
+	if err := mpb.client.decodeResponse(MiniProgramSecMsgEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "MiniProgramSecMsg.Do")
 	}
 	return ret, nil
 }
@@ -0,0 +1,117 @@
+package wechat
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// MiniProgramHandlerFunc handles a message pushed to a mini program. Mini
+// programs only take few passive replies, like transfer_customer_service,
+// see NewOfficeAccountTransferCustomerServiceReply: RawReply, string and
+// []byte are sent as they are, anything else is marshaled to the format of
+// the message, JSON or XML. If reply is nil, ReplySuccess is sent.
+type MiniProgramHandlerFunc func(ctx context.Context, msg MiniProgramMessage) (reply interface{}, err error)
+
+// MiniProgramServer 小程序消息推送服务器，is an http.Handler like
+// OfficeAccountServer for the JSON and XML formats of mini program push.
+type MiniProgramServer struct {
+	cs callbackServer
+}
+
+// NewMiniProgramServer return instance of MiniProgramServer
+func NewMiniProgramServer(client *Client) *MiniProgramServer {
+	mps := &MiniProgramServer{
+		cs: newCallbackServer(client, "MiniProgramServer", "appid", func(raw []byte) (callbackMessage, error) {
+			return DecodeMiniProgramMessage(raw)
+		}),
+	}
+	return mps
+}
+
+// SetAppID SetAppID
+func (mps *MiniProgramServer) SetAppID(appid string) *MiniProgramServer {
+	mps.cs.crypto.receiveID = appid
+	return mps
+}
+
+// SetToken 服务器配置的 Token
+func (mps *MiniProgramServer) SetToken(token string) *MiniProgramServer {
+	mps.cs.crypto.token = token
+	return mps
+}
+
+// SetEncodingAESKey 服务器配置的 EncodingAESKey，兼容模式和安全模式需要
+func (mps *MiniProgramServer) SetEncodingAESKey(aesKey string) *MiniProgramServer {
+	mps.cs.crypto.aesKey = aesKey
+	return mps
+}
+
+// SetMode CallbackModePlaintext (default), CallbackModeCompatible or
+// CallbackModeSafe
+func (mps *MiniProgramServer) SetMode(mode CallbackMode) *MiniProgramServer {
+	mps.cs.mode = mode
+	return mps
+}
+
+// HandleMessage registers the handler of a message type, e.g. MsgTypeText.
+func (mps *MiniProgramServer) HandleMessage(msgType string, handler MiniProgramHandlerFunc) *MiniProgramServer {
+	mps.cs.handlers[callbackRoute(msgType, "")] = mps.wrap(handler)
+	return mps
+}
+
+// HandleEvent registers the handler of an event, e.g. EventSubscribeMsgPopup.
+func (mps *MiniProgramServer) HandleEvent(event string, handler MiniProgramHandlerFunc) *MiniProgramServer {
+	mps.cs.handlers[callbackRoute(MsgTypeEvent, event)] = mps.wrap(handler)
+	return mps
+}
+
+// HandleDefault registers the handler of messages without a handler.
+// Without it, they are answered with ReplySuccess.
+func (mps *MiniProgramServer) HandleDefault(handler MiniProgramHandlerFunc) *MiniProgramServer {
+	mps.cs.fallback = mps.wrap(handler)
+	return mps
+}
+
+func (mps *MiniProgramServer) wrap(handler MiniProgramHandlerFunc) callbackHandler {
+	return func(ctx context.Context, msg callbackMessage) (interface{}, error) {
+		return handler(ctx, msg)
+	}
+}
+
+// SetDedupWindow sets how long messages are remembered to drop the
+// retries of wechat, DefaultCallbackDedupWindow by default, 0 disables it.
+// Retries are answered with the reply to the first delivery, a retry that
+// arrives while the handler still runs waits for its reply or is answered
+// with ReplySuccess. The client cache is used, share it and a Locker
+// between instances to deduplicate across them, see SetLocker.
+func (mps *MiniProgramServer) SetDedupWindow(window time.Duration) *MiniProgramServer {
+	mps.cs.setDedupWindow(window)
+	return mps
+}
+
+// SetAsync answers ReplySuccess right away and handles the messages with
+// workers, at most queueSize messages wait for them and more are answered
+// with 503, see ErrCallbackQueueFull. Passive replies of the handlers are
+// dropped, and the handlers get a context without deadline.
+func (mps *MiniProgramServer) SetAsync(workers, queueSize int) *MiniProgramServer {
+	mps.cs.setAsync(workers, queueSize)
+	return mps
+}
+
+// Close stops the workers of SetAsync once the queued messages are
+// handled, new messages are answered with 503 then.
+func (mps *MiniProgramServer) Close() error {
+	mps.cs.close()
+	return nil
+}
+
+// Validate checks if the server is valid.
+func (mps *MiniProgramServer) Validate() error {
+	return mps.cs.validate()
+}
+
+// ServeHTTP ServeHTTP
+func (mps *MiniProgramServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mps.cs.serveHTTP(w, r)
+}
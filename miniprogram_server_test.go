@@ -0,0 +1,142 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiniProgramServer(t *testing.T) {
+	client, err := NewClient()
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var got MiniProgramMessage
+	handler := func(ctx context.Context, msg MiniProgramMessage) (interface{}, error) {
+		got = msg
+		return nil, nil
+	}
+	server := client.MiniProgramServer().
+		SetAppID(testCallbackAppID).
+		SetToken(testCallbackToken).
+		SetEncodingAESKey(testCallbackAESKey).
+		SetMode(CallbackModeSafe).
+		HandleEvent(EventSubscribeMsgPopup, handler).
+		HandleEvent(EventSubscribeMsgSent, handler).
+		HandleEvent(EventWxaMediaCheck, handler)
+
+	popup := `{"ToUserName":"gh_123456789abc","FromUserName":"openid","CreateTime":"1620973045","MsgType":"event","Event":"subscribe_msg_popup_event","List":[{"TemplateId":"TID1","SubscribeStatusString":"accept","PopupScene":"0"},{"TemplateId":"TID2","SubscribeStatusString":"reject","PopupScene":"0"}]}`
+	sent := `<xml><ToUserName><![CDATA[gh_123456789abc]]></ToUserName><FromUserName><![CDATA[openid]]></FromUserName><CreateTime>1620963428</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[subscribe_msg_sent_event]]></Event><SubscribeMsgSentEvent><List><TemplateId><![CDATA[TID1]]></TemplateId><MsgID><![CDATA[1700827132819554304]]></MsgID><ErrorCode><![CDATA[0]]></ErrorCode><ErrorStatus><![CDATA[success]]></ErrorStatus></List></SubscribeMsgSentEvent></xml>`
+	mediaCheck := `{"ToUserName":"gh_38cc49f9733b","FromUserName":"oH1fu0FdHqpToe2T6gBj0WyB8iS1","CreateTime":1626959646,"MsgType":"event","Event":"wxa_media_check","appid":"wx8f16a5e5d9b6a2e1","trace_id":"60f96f1d-3845297a-1976a3ae","version":2,"detail":[{"strategy":"content_model","errcode":0,"suggest":"pass","label":100,"prob":90}],"errcode":0,"errmsg":"ok","result":{"suggest":"pass","label":100}}`
+
+	tests := []struct {
+		name     string
+		message  string
+		jsonBody bool
+		check    func(msg MiniProgramMessage) bool
+	}{
+		{
+			name:     "popup json",
+			message:  popup,
+			jsonBody: true,
+			check: func(msg MiniProgramMessage) bool {
+				e, ok := msg.(*MiniProgramSubscribeMsgPopupEvent)
+				return ok && e.CreateTime == 1620973045 && len(e.List) == 2 && e.List[1].SubscribeStatusString == "reject"
+			},
+		},
+		{
+			name:    "sent xml",
+			message: sent,
+			check: func(msg MiniProgramMessage) bool {
+				e, ok := msg.(*MiniProgramSubscribeMsgSentEvent)
+				return ok && len(e.List) == 1 && e.List[0].MsgID == "1700827132819554304" && e.List[0].ErrorCode == "0"
+			},
+		},
+		{
+			name:     "media check",
+			message:  mediaCheck,
+			jsonBody: true,
+			check: func(msg MiniProgramMessage) bool {
+				e, ok := msg.(*MiniProgramMediaCheckEvent)
+				return ok && e.Result.Suggest == "pass" && len(e.Detail) == 1 && e.TraceID == "60f96f1d-3845297a-1976a3ae"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			encrypted, err := EncryptMsg(RandomStr(16), []byte(tt.message), testCallbackAppID, testCallbackAESKey)
+			if err != nil {
+				t.Log(err)
+				t.FailNow()
+			}
+			body := `<xml><ToUserName><![CDATA[gh_123456789abc]]></ToUserName><Encrypt><![CDATA[` + string(encrypted) + `]]></Encrypt></xml>`
+			if tt.jsonBody {
+				b, _ := json.Marshal(map[string]string{"ToUserName": "gh_123456789abc", "Encrypt": string(encrypted)})
+				body = string(b)
+			}
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, signedCallbackURL(string(encrypted)), strings.NewReader(body)))
+			if w.Code != http.StatusOK || w.Body.String() != string(ReplySuccess) || got == nil || !tt.check(got) {
+				t.Log(w.Code, w.Body.String(), got)
+				t.FailNow()
+			}
+		})
+	}
+
+	// plaintext is refused in safe mode
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, signedCallbackURL(""), strings.NewReader(popup)))
+	if w.Code != http.StatusBadRequest {
+		t.Log(w.Code, w.Body.String())
+		t.FailNow()
+	}
+}
+
+func TestMiniProgramServer_TransferCustomerService(t *testing.T) {
+	client, err := NewClient()
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	server := client.MiniProgramServer().
+		SetToken(testCallbackToken).
+		HandleMessage(MsgTypeText, func(ctx context.Context, msg MiniProgramMessage) (interface{}, error) {
+			reply := NewOfficeAccountTransferCustomerServiceReply(msg, msg.(*MiniProgramTextMessage).Content)
+			reply.CreateTime = 1482048670
+			return reply, nil
+		})
+
+	text := `{"ToUserName":"gh_123456789abc","FromUserName":"openid","CreateTime":1482048670,"MsgType":"text","Content":"%s","MsgId":%d}`
+	tests := []struct {
+		name      string
+		kfAccount string
+		wantReply string
+	}{
+		{
+			name:      "any account",
+			wantReply: `{"ToUserName":"openid","FromUserName":"gh_123456789abc","CreateTime":1482048670,"MsgType":"transfer_customer_service"}`,
+		},
+		{
+			name:      "kf account",
+			kfAccount: "test1@test",
+			wantReply: `{"ToUserName":"openid","FromUserName":"gh_123456789abc","CreateTime":1482048670,"MsgType":"transfer_customer_service","TransInfo":{"KfAccount":"test1@test"}}`,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			body := fmt.Sprintf(text, tt.kfAccount, i+1)
+			server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, signedCallbackURL(""), strings.NewReader(body)))
+			if w.Code != http.StatusOK || w.Body.String() != tt.wantReply || w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+				t.Log(w.Code, w.Body.String())
+				t.FailNow()
+			}
+		})
+	}
+}
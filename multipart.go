@@ -0,0 +1,248 @@
+package wechat
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// var of multipart
+var (
+	// ErrMultipartNotReplayable is raised when a request with a Multipart
+	// body is retried, but a file part can't be read again because its
+	// reader isn't an io.Seeker.
+	ErrMultipartNotReplayable = errors.New("wechat: multipart file part can't be replayed")
+
+	errMultipartReplaced = errors.New("wechat: multipart body replaced by a new attempt")
+)
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// multipartPart is a text field or a file part of a Multipart.
+type multipartPart struct {
+	fieldName   string
+	fileName    string
+	contentType string
+	value       string    // text field
+	reader      io.Reader // file part
+	size        int64     // size of the file, -1 if unknown
+	offset      int64     // start of the file if reader is an io.Seeker
+}
+
+// header returns the header of the part, fileLength adds the size of a
+// file to its Content-Disposition, see Multipart.SetFileLength.
+func (p *multipartPart) header(fileLength bool) textproto.MIMEHeader {
+	h := make(textproto.MIMEHeader)
+	if p.reader == nil {
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(p.fieldName)))
+		return h
+	}
+	disposition := fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(p.fieldName), quoteEscaper.Replace(p.fileName))
+	if fileLength && p.size >= 0 {
+		disposition += fmt.Sprintf("; filelength=%d", p.size)
+	}
+	h.Set("Content-Disposition", disposition)
+	h.Set("Content-Type", p.contentType)
+	return h
+}
+
+// Multipart is a multipart/form-data body with text fields and file parts,
+// see PerformRequestOptions.Multipart. Files are streamed from their readers
+// while the request is sent. If a request is retried, the files are read
+// again, which requires io.Seeker readers.
+type Multipart struct {
+	boundary   string
+	parts      []*multipartPart
+	fileLength bool // add filelength to the file parts, see SetFileLength
+
+	mu   sync.Mutex
+	sent bool           // true once the body was opened
+	pr   *io.PipeReader // body of the last attempt
+	done chan struct{}  // closed when the last attempt is written
+}
+
+// NewMultipart returns an empty Multipart.
+func NewMultipart() *Multipart {
+	return &Multipart{
+		boundary: multipart.NewWriter(ioutil.Discard).Boundary(),
+	}
+}
+
+// SetFileLength adds the size of the files to their Content-Disposition as
+// filelength, which WeCom media/upload requires. Files of unknown size are
+// sent without it.
+func (m *Multipart) SetFileLength(enabled bool) *Multipart {
+	m.fileLength = enabled
+	return m
+}
+
+// AddField adds a text field, e.g. the description of a video material.
+func (m *Multipart) AddField(fieldName, value string) *Multipart {
+	m.parts = append(m.parts, &multipartPart{fieldName: fieldName, value: value})
+	return m
+}
+
+// AddFile adds a file part read from r. size is the length of the file, or
+// -1 if unknown, in which case the request is sent chunked. contentType
+// defaults to application/octet-stream.
+func (m *Multipart) AddFile(fieldName, fileName, contentType string, r io.Reader, size int64) *Multipart {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	p := &multipartPart{
+		fieldName:   fieldName,
+		fileName:    fileName,
+		contentType: contentType,
+		reader:      r,
+		size:        size,
+	}
+	if s, ok := r.(io.Seeker); ok {
+		if offset, err := s.Seek(0, io.SeekCurrent); err == nil {
+			p.offset = offset
+		}
+	}
+	m.parts = append(m.parts, p)
+	return m
+}
+
+// AddFileBytes adds a file part from memory.
+func (m *Multipart) AddFileBytes(fieldName, fileName, contentType string, b []byte) *Multipart {
+	return m.AddFile(fieldName, fileName, contentType, bytes.NewReader(b), int64(len(b)))
+}
+
+// ContentType returns the Content-Type header of the body.
+func (m *Multipart) ContentType() string {
+	return "multipart/form-data; boundary=" + m.boundary
+}
+
+// ContentLength returns the length of the body, or -1 if the size of a
+// file is unknown.
+func (m *Multipart) ContentLength() int64 {
+	cw := &countingWriter{}
+	mw := multipart.NewWriter(cw)
+	mw.SetBoundary(m.boundary)
+	var n int64
+	for _, p := range m.parts {
+		if _, err := mw.CreatePart(p.header(m.fileLength)); err != nil {
+			return -1
+		}
+		if p.reader == nil {
+			n += int64(len(p.value))
+			continue
+		}
+		if p.size < 0 {
+			return -1
+		}
+		n += p.size
+	}
+	if err := mw.Close(); err != nil {
+		return -1
+	}
+	return cw.n + n
+}
+
+// Validate checks if the body is valid.
+func (m *Multipart) Validate() error {
+	if len(m.parts) == 0 {
+		return fmt.Errorf("multipart has no parts")
+	}
+	for _, p := range m.parts {
+		if p.fieldName == "" {
+			return fmt.Errorf("multipart part without field name")
+		}
+		if p.reader != nil && p.fileName == "" {
+			return fmt.Errorf("multipart file part %q without file name", p.fieldName)
+		}
+	}
+	return nil
+}
+
+// open returns a reader of the body that is written by a goroutine. The
+// files of an earlier attempt are rewound.
+func (m *Multipart) open() (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sent {
+		// Stop writing the last attempt before touching the readers.
+		m.pr.CloseWithError(errMultipartReplaced)
+		<-m.done
+		if err := m.rewind(); err != nil {
+			return nil, err
+		}
+	}
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(m.write(pw))
+	}()
+	m.sent, m.pr, m.done = true, pr, done
+	return pr, nil
+}
+
+// rewind seeks the files back to their start.
+func (m *Multipart) rewind() error {
+	for _, p := range m.parts {
+		if p.reader == nil {
+			continue
+		}
+		s, ok := p.reader.(io.Seeker)
+		if !ok {
+			return errors.Wrap(ErrMultipartNotReplayable, p.fieldName)
+		}
+		if _, err := s.Seek(p.offset, io.SeekStart); err != nil {
+			return errors.Wrap(err, "Multipart.rewind")
+		}
+	}
+	return nil
+}
+
+// write writes the body to w.
+func (m *Multipart) write(w io.Writer) error {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(m.boundary); err != nil {
+		return err
+	}
+	for _, p := range m.parts {
+		pw, err := mw.CreatePart(p.header(m.fileLength))
+		if err != nil {
+			return err
+		}
+		if p.reader == nil {
+			if _, err := io.WriteString(pw, p.value); err != nil {
+				return err
+			}
+			continue
+		}
+		if p.size < 0 {
+			if _, err := io.Copy(pw, p.reader); err != nil {
+				return err
+			}
+			continue
+		}
+		// Content-Length was announced, so the file must have exactly size
+		// bytes.
+		if _, err := io.CopyN(pw, p.reader, p.size); err != nil {
+			return errors.Wrapf(err, "multipart file part %q is shorter than %d bytes", p.fieldName, p.size)
+		}
+	}
+	return mw.Close()
+}
+
+// countingWriter counts the bytes written to it.
+type countingWriter struct {
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	cw.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,86 @@
+package wechat
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_PerformFormRequest(t *testing.T) {
+	video := bytes.Repeat([]byte("video"), 1000)
+	var hits int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.ContentLength <= int64(len(video)) {
+			t.Log("Content-Length should be set", r.ContentLength)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f, fh, err := r.FormFile("media")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		b, _ := ioutil.ReadAll(f)
+		disposition := fh.Header.Get("Content-Disposition")
+		if !bytes.Equal(b, video) || strings.Contains(disposition, "filelength") ||
+			fh.Header.Get("Content-Type") != "video/mp4" || r.FormValue("description") != `{"title":"title","introduction":"intro"}` {
+			t.Log(len(b), fh.Header, r.FormValue("description"))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if hits == 1 {
+			w.Write([]byte(`{"errcode":-1,"errmsg":"system error"}`))
+			return
+		}
+		w.Write([]byte(`{"media_id":"MEDIA_ID"}`))
+	}))
+	defer ts.Close()
+	client, err := NewClient(
+		SetHostURL(OfficeAccountHost, ts.URL),
+		SetRetrier(NewBackoffRetrier(NewConstantBackoff(time.Millisecond, 1))),
+	)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	tests := []struct {
+		name    string
+		media   func() io.Reader
+		wantErr bool
+	}{
+		{name: "seekable", media: func() io.Reader { return bytes.NewReader(video) }, wantErr: false},
+		{name: "not seekable", media: func() io.Reader { return bytes.NewBuffer(video) }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits = 0
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			ret, err := client.OfficeAccountMaterialAdd().SetAccessToken("token").SetType("video").
+				SetMedia("video.mp4", "video/mp4", tt.media(), int64(len(video))).
+				SetDescription("title", "intro").Do(ctx)
+			if tt.wantErr {
+				if err == nil || hits != 1 {
+					t.Log(ret, err, hits)
+					t.FailNow()
+				}
+				return
+			}
+			if err != nil || ret.MediaID != "MEDIA_ID" || hits != 2 {
+				t.Log(ret, err, hits)
+				t.FailNow()
+			}
+		})
+	}
+}
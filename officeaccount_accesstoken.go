@@ -80,7 +80,7 @@ func (mpat *OfficeAccountAccessToken) Do(ctx context.Context) (*OfficeAccountAcc
 	res, err := mpat.client.PerformRequest(ctx, PerformRequestOptions{
 		Method:   http.MethodGet,
 		Params:   params,
-		BaseURI:  OfficeAccountBaseHost,
+		BaseURI:  OfficeAccountHost,
 		Endpoint: OfficeAccountAccessTokenEndpoint,
 	})
 	if err != nil {
@@ -88,8 +88,8 @@ func (mpat *OfficeAccountAccessToken) Do(ctx context.Context) (*OfficeAccountAcc
 	}
 	// Return operation response
 	ret := new(OfficeAccountAccessTokenResponse)
-	if err := mpat.client.decoder.Decode(res.Body, ret); err != nil {
-		return nil, errors.Wrap(err, "OfficeAccountAccessToken.Do")
+	if err := mpat.client.decodeResponse(OfficeAccountAccessTokenEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountAccessToken.Do")
 	}
 	return ret, nil
 }
@@ -100,8 +100,7 @@ func (mpat *OfficeAccountAccessToken) Credentials(ctx context.Context) (*AccessT
 	if err != nil {
 		return nil, errors.Wrap(err, "OfficeAccountAccessToken.Credentials")
 	}
-	if res.ErrCode != 0 {
-		err = fmt.Errorf("errcode: %v, errmsg: %s", res.ErrCode, res.ErrMsg)
+	if err := DecodeWithCommonError(OfficeAccountAccessTokenEndpoint, res.CommonError); err != nil {
 		return nil, errors.Wrap(err, "OfficeAccountAccessToken.Credentials")
 	}
 	at := &AccessToken{
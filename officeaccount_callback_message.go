@@ -0,0 +1,151 @@
+package wechat
+
+import (
+	"encoding/xml"
+)
+
+// OfficeAccountMessage is a message or event pushed to an office account,
+// one of the OfficeAccount*Message and OfficeAccount*Event types, or
+// *OfficeAccountUnknownMessage.
+type OfficeAccountMessage interface {
+	MessageHeader() *CallbackMessageHeader
+}
+
+// -- messages --
+
+// OfficeAccountTextMessage 文本消息
+type OfficeAccountTextMessage struct {
+	CallbackMessageHeader
+	MsgID   int64  `xml:"MsgId"`
+	Content string `xml:"Content"`
+}
+
+// OfficeAccountImageMessage 图片消息
+type OfficeAccountImageMessage struct {
+	CallbackMessageHeader
+	MsgID   int64  `xml:"MsgId"`
+	PicURL  string `xml:"PicUrl"`
+	MediaID string `xml:"MediaId"`
+}
+
+// OfficeAccountVoiceMessage 语音消息，Recognition 为语音识别结果
+type OfficeAccountVoiceMessage struct {
+	CallbackMessageHeader
+	MsgID       int64  `xml:"MsgId"`
+	MediaID     string `xml:"MediaId"`
+	Format      string `xml:"Format"`
+	Recognition string `xml:"Recognition"`
+}
+
+// OfficeAccountVideoMessage 视频消息和小视频消息
+type OfficeAccountVideoMessage struct {
+	CallbackMessageHeader
+	MsgID        int64  `xml:"MsgId"`
+	MediaID      string `xml:"MediaId"`
+	ThumbMediaID string `xml:"ThumbMediaId"`
+}
+
+// OfficeAccountLocationMessage 地理位置消息
+type OfficeAccountLocationMessage struct {
+	CallbackMessageHeader
+	MsgID     int64   `xml:"MsgId"`
+	LocationX float64 `xml:"Location_X"`
+	LocationY float64 `xml:"Location_Y"`
+	Scale     int64   `xml:"Scale"`
+	Label     string  `xml:"Label"`
+}
+
+// OfficeAccountLinkMessage 链接消息
+type OfficeAccountLinkMessage struct {
+	CallbackMessageHeader
+	MsgID       int64  `xml:"MsgId"`
+	Title       string `xml:"Title"`
+	Description string `xml:"Description"`
+	URL         string `xml:"Url"`
+}
+
+// -- events --
+
+// OfficeAccountSubscribeEvent 关注和取消关注事件。扫描带参数二维码关注时，
+// EventKey 为 qrscene_ 加二维码的参数
+type OfficeAccountSubscribeEvent struct {
+	CallbackMessageHeader
+	EventKey string `xml:"EventKey"`
+	Ticket   string `xml:"Ticket"`
+}
+
+// OfficeAccountScanEvent 已关注用户扫描带参数二维码事件
+type OfficeAccountScanEvent struct {
+	CallbackMessageHeader
+	EventKey string `xml:"EventKey"`
+	Ticket   string `xml:"Ticket"`
+}
+
+// OfficeAccountLocationEvent 上报地理位置事件
+type OfficeAccountLocationEvent struct {
+	CallbackMessageHeader
+	Latitude  float64 `xml:"Latitude"`
+	Longitude float64 `xml:"Longitude"`
+	Precision float64 `xml:"Precision"`
+}
+
+// OfficeAccountMenuEvent 自定义菜单事件，CLICK 的 EventKey 为菜单 key，
+// VIEW 的 EventKey 为跳转的 url
+type OfficeAccountMenuEvent struct {
+	CallbackMessageHeader
+	EventKey string `xml:"EventKey"`
+	MenuID   string `xml:"MenuId"`
+}
+
+// OfficeAccountTemplateSendJobFinishEvent 模板消息发送结果，Status 为
+// success、failed:user block 或 failed: system failed
+type OfficeAccountTemplateSendJobFinishEvent struct {
+	CallbackMessageHeader
+	MsgID  int64  `xml:"MsgID"`
+	Status string `xml:"Status"`
+}
+
+// OfficeAccountUnknownMessage is a message or event without a type in
+// this package, Raw is the decrypted XML.
+type OfficeAccountUnknownMessage struct {
+	CallbackMessageHeader
+	Raw []byte `xml:"-"`
+}
+
+// officeAccountMessageTypes returns new messages by route, see
+// callbackRoute.
+var officeAccountMessageTypes = map[string]func() OfficeAccountMessage{
+	MsgTypeText:       func() OfficeAccountMessage { return new(OfficeAccountTextMessage) },
+	MsgTypeImage:      func() OfficeAccountMessage { return new(OfficeAccountImageMessage) },
+	MsgTypeVoice:      func() OfficeAccountMessage { return new(OfficeAccountVoiceMessage) },
+	MsgTypeVideo:      func() OfficeAccountMessage { return new(OfficeAccountVideoMessage) },
+	MsgTypeShortVideo: func() OfficeAccountMessage { return new(OfficeAccountVideoMessage) },
+	MsgTypeLocation:   func() OfficeAccountMessage { return new(OfficeAccountLocationMessage) },
+	MsgTypeLink:       func() OfficeAccountMessage { return new(OfficeAccountLinkMessage) },
+
+	callbackRoute(MsgTypeEvent, EventSubscribe):             func() OfficeAccountMessage { return new(OfficeAccountSubscribeEvent) },
+	callbackRoute(MsgTypeEvent, EventUnsubscribe):           func() OfficeAccountMessage { return new(OfficeAccountSubscribeEvent) },
+	callbackRoute(MsgTypeEvent, EventScan):                  func() OfficeAccountMessage { return new(OfficeAccountScanEvent) },
+	callbackRoute(MsgTypeEvent, EventLocation):              func() OfficeAccountMessage { return new(OfficeAccountLocationEvent) },
+	callbackRoute(MsgTypeEvent, EventClick):                 func() OfficeAccountMessage { return new(OfficeAccountMenuEvent) },
+	callbackRoute(MsgTypeEvent, EventView):                  func() OfficeAccountMessage { return new(OfficeAccountMenuEvent) },
+	callbackRoute(MsgTypeEvent, EventTemplateSendJobFinish): func() OfficeAccountMessage { return new(OfficeAccountTemplateSendJobFinishEvent) },
+}
+
+// DecodeOfficeAccountMessage decodes the plaintext XML of a message or
+// event into its type.
+func DecodeOfficeAccountMessage(raw []byte) (OfficeAccountMessage, error) {
+	header := new(CallbackMessageHeader)
+	if err := xml.Unmarshal(raw, header); err != nil {
+		return nil, err
+	}
+	newMessage, ok := officeAccountMessageTypes[callbackRoute(header.MsgType, header.Event)]
+	if !ok {
+		return &OfficeAccountUnknownMessage{CallbackMessageHeader: *header, Raw: raw}, nil
+	}
+	msg := newMessage()
+	if err := xml.Unmarshal(raw, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
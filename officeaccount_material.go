@@ -0,0 +1,156 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// OfficeAccountMaterialAddEndpoint Endpoint
+	OfficeAccountMaterialAddEndpoint = "cgi-bin/material/add_material"
+)
+
+// allowed types of permanent materials
+var allowedMaterialType = map[string]struct{}{
+	"image": {},
+	"voice": {},
+	"video": {},
+	"thumb": {},
+}
+
+// OfficeAccountMaterialAdd 新增其他类型永久素材，视频素材需要 description。
+// The file is streamed from its reader.
+type OfficeAccountMaterialAdd struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	mediaType   string
+	fileName    string
+	contentType string
+	reader      io.Reader
+	size        int64
+	description *OfficeAccountMaterialDescription
+}
+
+// OfficeAccountMaterialDescription 视频素材的描述
+type OfficeAccountMaterialDescription struct {
+	Title        string `json:"title"`
+	Introduction string `json:"introduction"`
+}
+
+// NewOfficeAccountMaterialAdd return instance of OfficeAccountMaterialAdd
+func NewOfficeAccountMaterialAdd(client *Client) *OfficeAccountMaterialAdd {
+	oama := &OfficeAccountMaterialAdd{
+		client: client,
+	}
+	return oama
+}
+
+// SetAccessToken SetAccessToken
+func (oama *OfficeAccountMaterialAdd) SetAccessToken(accessToken string) *OfficeAccountMaterialAdd {
+	oama.accessToken = accessToken
+	return oama
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oama *OfficeAccountMaterialAdd) SetAccessTokenSource(iat IAccessToken) *OfficeAccountMaterialAdd {
+	oama.iat = iat
+	return oama
+}
+
+// SetType 媒体文件类型，image、voice、video 或 thumb
+func (oama *OfficeAccountMaterialAdd) SetType(mediaType string) *OfficeAccountMaterialAdd {
+	oama.mediaType = mediaType
+	return oama
+}
+
+// SetMedia sets the file read from r, size is its length. Use an io.Seeker
+// like *os.File if the upload should be retried.
+func (oama *OfficeAccountMaterialAdd) SetMedia(fileName, contentType string, r io.Reader, size int64) *OfficeAccountMaterialAdd {
+	oama.fileName = fileName
+	oama.contentType = contentType
+	oama.reader = r
+	oama.size = size
+	return oama
+}
+
+// SetDescription 视频素材的标题和描述
+func (oama *OfficeAccountMaterialAdd) SetDescription(title, introduction string) *OfficeAccountMaterialAdd {
+	oama.description = &OfficeAccountMaterialDescription{
+		Title:        title,
+		Introduction: introduction,
+	}
+	return oama
+}
+
+// Validate checks if the operation is valid.
+func (oama *OfficeAccountMaterialAdd) Validate() error {
+	var invalid []string
+	if oama.reader == nil || oama.fileName == "" {
+		invalid = append(invalid, "media")
+	}
+	if oama.mediaType == "video" && oama.description == nil {
+		invalid = append(invalid, "description")
+	}
+	if oama.accessToken == "" && oama.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	if _, ok := allowedMaterialType[oama.mediaType]; !ok {
+		return fmt.Errorf("not allowed type %q", oama.mediaType)
+	}
+	return nil
+}
+
+// Do Do
+func (oama *OfficeAccountMaterialAdd) Do(ctx context.Context) (*OfficeAccountMaterialAddResponse, error) {
+	// Check pre-conditions
+	if err := oama.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMaterialAdd.Do")
+	}
+	body := NewMultipart().AddFile("media", oama.fileName, oama.contentType, oama.reader, oama.size)
+	if oama.description != nil {
+		description, err := json.Marshal(oama.description)
+		if err != nil {
+			return nil, errors.Wrap(err, "OfficeAccountMaterialAdd.Do")
+		}
+		body.AddField("description", string(description))
+	}
+	// url params
+	params := url.Values{}
+	params.Set("type", oama.mediaType)
+	// PerformRequest
+	res, err := oama.client.performTokenFormRequest(ctx, oama.accessToken, oama.iat, PerformRequestOptions{
+		Method:    http.MethodPost,
+		Params:    params,
+		Multipart: body,
+		BaseURI:   OfficeAccountHost,
+		Endpoint:  OfficeAccountMaterialAddEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMaterialAdd.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountMaterialAddResponse)
+	if err := oama.client.decodeResponse(OfficeAccountMaterialAddEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountMaterialAdd.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountMaterialAddResponse OfficeAccountMaterialAddResponse
+type OfficeAccountMaterialAddResponse struct {
+	CommonError
+	MediaID string `json:"media_id"`
+	URL     string `json:"url"` // 图片素材的 URL
+}
@@ -0,0 +1,694 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// Endpoint https://developers.weixin.qq.com/doc/offiaccount/Custom_Menus/Creating_Custom-Defined_Menu.html
+const (
+	OfficeAccountMenuCreateEndpoint         = "cgi-bin/menu/create"
+	OfficeAccountMenuGetCurrentEndpoint     = "cgi-bin/get_current_selfmenu_info"
+	OfficeAccountMenuDeleteEndpoint         = "cgi-bin/menu/delete"
+	OfficeAccountMenuAddConditionalEndpoint = "cgi-bin/menu/addconditional"
+	OfficeAccountMenuDelConditionalEndpoint = "cgi-bin/menu/delconditional"
+	OfficeAccountMenuTryMatchEndpoint       = "cgi-bin/menu/trymatch"
+)
+
+// types of menu buttons
+const (
+	MenuButtonClick              = "click"
+	MenuButtonView               = "view"
+	MenuButtonMiniProgram        = "miniprogram"
+	MenuButtonScancodePush       = "scancode_push"
+	MenuButtonScancodeWaitMsg    = "scancode_waitmsg"
+	MenuButtonPicSysPhoto        = "pic_sysphoto"
+	MenuButtonPicPhotoOrAlbum    = "pic_photo_or_album"
+	MenuButtonPicWeixin          = "pic_weixin"
+	MenuButtonLocationSelect     = "location_select"
+	MenuButtonMediaID            = "media_id"
+	MenuButtonArticleID          = "article_id"
+	MenuButtonArticleViewLimited = "article_view_limited"
+)
+
+// limits of menus, names and keys are counted in bytes
+const (
+	menuMaxButtons    = 3
+	menuMaxSubButtons = 5
+	menuMaxName       = 16
+	menuMaxSubName    = 60
+	menuMaxKey        = 128
+	menuMaxURL        = 1024
+)
+
+// OfficeAccountMenuButton 菜单按钮。A button either has a Type and the
+// fields it needs, or SubButtons only:
+//
+//	click, scancode_*, pic_*, location_select   Key
+//	view                                         URL
+//	miniprogram                                  URL (for old clients), AppID, PagePath
+//	media_id                                     MediaID
+//	article_id, article_view_limited             ArticleID
+type OfficeAccountMenuButton struct {
+	Type       string                    `json:"type,omitempty"`
+	Name       string                    `json:"name"`
+	Key        string                    `json:"key,omitempty"`
+	URL        string                    `json:"url,omitempty"`
+	MediaID    string                    `json:"media_id,omitempty"`
+	ArticleID  string                    `json:"article_id,omitempty"`
+	AppID      string                    `json:"appid,omitempty"`
+	PagePath   string                    `json:"pagepath,omitempty"`
+	SubButtons []OfficeAccountMenuButton `json:"sub_button,omitempty"`
+}
+
+// OfficeAccountMenuMatchRule 个性化菜单匹配规则，至少设置一个字段。Sex 1 男 2 女，
+// ClientPlatformType 1 iOS 2 Android 3 Others
+type OfficeAccountMenuMatchRule struct {
+	TagID              string `json:"tag_id,omitempty"`
+	Sex                string `json:"sex,omitempty"`
+	Country            string `json:"country,omitempty"`
+	Province           string `json:"province,omitempty"`
+	City               string `json:"city,omitempty"`
+	ClientPlatformType string `json:"client_platform_type,omitempty"`
+	Language           string `json:"language,omitempty"`
+}
+
+// validateMenuButtons checks the buttons against the limits of wechat, so
+// a menu is not refused after the request.
+func validateMenuButtons(buttons []OfficeAccountMenuButton) error {
+	if len(buttons) == 0 {
+		return fmt.Errorf("missing required fields: %v", []string{"button"})
+	}
+	if len(buttons) > menuMaxButtons {
+		return fmt.Errorf("too many buttons: %d > %d", len(buttons), menuMaxButtons)
+	}
+	for _, button := range buttons {
+		if err := validateMenuButton(button, menuMaxName); err != nil {
+			return err
+		}
+		if len(button.SubButtons) == 0 {
+			continue
+		}
+		if len(button.SubButtons) > menuMaxSubButtons {
+			return fmt.Errorf("too many sub buttons of %q: %d > %d", button.Name, len(button.SubButtons), menuMaxSubButtons)
+		}
+		for _, sub := range button.SubButtons {
+			if len(sub.SubButtons) > 0 {
+				return fmt.Errorf("sub button %q has sub buttons", sub.Name)
+			}
+			if err := validateMenuButton(sub, menuMaxSubName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateMenuButton(button OfficeAccountMenuButton, maxName int) error {
+	if button.Name == "" {
+		return fmt.Errorf("missing required fields: %v", []string{"name"})
+	}
+	if len(button.Name) > maxName {
+		return fmt.Errorf("name of %q is longer than %d bytes", button.Name, maxName)
+	}
+	if len(button.SubButtons) > 0 {
+		if button.Type != "" {
+			return fmt.Errorf("button %q has both type and sub buttons", button.Name)
+		}
+		return nil
+	}
+	var invalid []string
+	switch button.Type {
+	case MenuButtonClick, MenuButtonScancodePush, MenuButtonScancodeWaitMsg,
+		MenuButtonPicSysPhoto, MenuButtonPicPhotoOrAlbum, MenuButtonPicWeixin, MenuButtonLocationSelect:
+		if button.Key == "" {
+			invalid = append(invalid, "key")
+		}
+	case MenuButtonView:
+		if button.URL == "" {
+			invalid = append(invalid, "url")
+		}
+	case MenuButtonMiniProgram:
+		if button.URL == "" {
+			invalid = append(invalid, "url")
+		}
+		if button.AppID == "" {
+			invalid = append(invalid, "appid")
+		}
+		if button.PagePath == "" {
+			invalid = append(invalid, "pagepath")
+		}
+	case MenuButtonMediaID:
+		if button.MediaID == "" {
+			invalid = append(invalid, "media_id")
+		}
+	case MenuButtonArticleID, MenuButtonArticleViewLimited:
+		if button.ArticleID == "" {
+			invalid = append(invalid, "article_id")
+		}
+	case "":
+		return fmt.Errorf("button %q has neither type nor sub buttons", button.Name)
+	default:
+		return fmt.Errorf("not allowed type %q of button %q", button.Type, button.Name)
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields of button %q: %v", button.Name, invalid)
+	}
+	if len(button.Key) > menuMaxKey {
+		return fmt.Errorf("key of %q is longer than %d bytes", button.Name, menuMaxKey)
+	}
+	if len(button.URL) > menuMaxURL {
+		return fmt.Errorf("url of %q is longer than %d bytes", button.Name, menuMaxURL)
+	}
+	return nil
+}
+
+// Validate checks if the match rule is valid.
+func (r *OfficeAccountMenuMatchRule) Validate() error {
+	if r == nil || *r == (OfficeAccountMenuMatchRule{}) {
+		return fmt.Errorf("missing required fields: %v", []string{"matchrule"})
+	}
+	return nil
+}
+
+// -- create --
+
+// OfficeAccountMenuCreate 创建自定义菜单，会覆盖现有菜单
+type OfficeAccountMenuCreate struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	buttons     []OfficeAccountMenuButton
+}
+
+// NewOfficeAccountMenuCreate return instance of OfficeAccountMenuCreate
+func NewOfficeAccountMenuCreate(client *Client) *OfficeAccountMenuCreate {
+	oamc := &OfficeAccountMenuCreate{
+		client: client,
+	}
+	return oamc
+}
+
+// SetAccessToken SetAccessToken
+func (oamc *OfficeAccountMenuCreate) SetAccessToken(accessToken string) *OfficeAccountMenuCreate {
+	oamc.accessToken = accessToken
+	return oamc
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oamc *OfficeAccountMenuCreate) SetAccessTokenSource(iat IAccessToken) *OfficeAccountMenuCreate {
+	oamc.iat = iat
+	return oamc
+}
+
+// SetButtons 一级菜单，最多 3 个，每个最多 5 个二级菜单
+func (oamc *OfficeAccountMenuCreate) SetButtons(buttons ...OfficeAccountMenuButton) *OfficeAccountMenuCreate {
+	oamc.buttons = buttons
+	return oamc
+}
+
+// Validate checks if the operation is valid.
+func (oamc *OfficeAccountMenuCreate) Validate() error {
+	if oamc.accessToken == "" && oamc.iat == nil {
+		return fmt.Errorf("missing required fields: %v", []string{"access_token"})
+	}
+	return validateMenuButtons(oamc.buttons)
+}
+
+// Do Do
+func (oamc *OfficeAccountMenuCreate) Do(ctx context.Context) (*OfficeAccountMenuCreateResponse, error) {
+	// Check pre-conditions
+	if err := oamc.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuCreate.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]interface{}{
+		"button": oamc.buttons,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuCreate.Do")
+	}
+	res, err := oamc.client.performTokenRequest(ctx, oamc.accessToken, oamc.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountMenuCreateEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuCreate.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountMenuCreateResponse)
+	if err := oamc.client.decodeResponse(OfficeAccountMenuCreateEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountMenuCreate.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountMenuCreateResponse OfficeAccountMenuCreateResponse
+type OfficeAccountMenuCreateResponse struct {
+	CommonError
+}
+
+// -- get current --
+
+// OfficeAccountMenuGetCurrent 查询当前使用的自定义菜单，包括在公众平台官网
+// 设置的菜单
+type OfficeAccountMenuGetCurrent struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+}
+
+// NewOfficeAccountMenuGetCurrent return instance of OfficeAccountMenuGetCurrent
+func NewOfficeAccountMenuGetCurrent(client *Client) *OfficeAccountMenuGetCurrent {
+	oamg := &OfficeAccountMenuGetCurrent{
+		client: client,
+	}
+	return oamg
+}
+
+// SetAccessToken SetAccessToken
+func (oamg *OfficeAccountMenuGetCurrent) SetAccessToken(accessToken string) *OfficeAccountMenuGetCurrent {
+	oamg.accessToken = accessToken
+	return oamg
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oamg *OfficeAccountMenuGetCurrent) SetAccessTokenSource(iat IAccessToken) *OfficeAccountMenuGetCurrent {
+	oamg.iat = iat
+	return oamg
+}
+
+// Validate checks if the operation is valid.
+func (oamg *OfficeAccountMenuGetCurrent) Validate() error {
+	if oamg.accessToken == "" && oamg.iat == nil {
+		return fmt.Errorf("missing required fields: %v", []string{"access_token"})
+	}
+	return nil
+}
+
+// Do Do
+func (oamg *OfficeAccountMenuGetCurrent) Do(ctx context.Context) (*OfficeAccountMenuGetCurrentResponse, error) {
+	// Check pre-conditions
+	if err := oamg.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuGetCurrent.Do")
+	}
+	res, err := oamg.client.performTokenRequest(ctx, oamg.accessToken, oamg.iat, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   url.Values{},
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountMenuGetCurrentEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuGetCurrent.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountMenuGetCurrentResponse)
+	if err := oamg.client.decodeResponse(OfficeAccountMenuGetCurrentEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountMenuGetCurrent.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountSelfMenuNews 图文消息菜单的图文
+type OfficeAccountSelfMenuNews struct {
+	Title      string `json:"title"`
+	Author     string `json:"author"`
+	Digest     string `json:"digest"`
+	ShowCover  int64  `json:"show_cover"`
+	CoverURL   string `json:"cover_url"`
+	ContentURL string `json:"content_url"`
+	SourceURL  string `json:"source_url"`
+}
+
+// OfficeAccountSelfMenuButton 当前菜单的按钮。Menus set on the website have
+// the types text, img, voice, video and news, whose content is in Value
+// or NewsInfo.
+type OfficeAccountSelfMenuButton struct {
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+	URL       string `json:"url"`
+	Value     string `json:"value"`
+	AppID     string `json:"appid"`
+	PagePath  string `json:"pagepath"`
+	ArticleID string `json:"article_id"`
+	NewsInfo  struct {
+		List []OfficeAccountSelfMenuNews `json:"list"`
+	} `json:"news_info"`
+	SubButton struct {
+		List []OfficeAccountSelfMenuButton `json:"list"`
+	} `json:"sub_button"`
+}
+
+// OfficeAccountMenuGetCurrentResponse OfficeAccountMenuGetCurrentResponse
+type OfficeAccountMenuGetCurrentResponse struct {
+	CommonError
+	IsMenuOpen   int64 `json:"is_menu_open"`
+	SelfMenuInfo struct {
+		Buttons []OfficeAccountSelfMenuButton `json:"button"`
+	} `json:"selfmenu_info"`
+}
+
+// -- delete --
+
+// OfficeAccountMenuDelete 删除自定义菜单，个性化菜单也会被删除
+type OfficeAccountMenuDelete struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+}
+
+// NewOfficeAccountMenuDelete return instance of OfficeAccountMenuDelete
+func NewOfficeAccountMenuDelete(client *Client) *OfficeAccountMenuDelete {
+	oamd := &OfficeAccountMenuDelete{
+		client: client,
+	}
+	return oamd
+}
+
+// SetAccessToken SetAccessToken
+func (oamd *OfficeAccountMenuDelete) SetAccessToken(accessToken string) *OfficeAccountMenuDelete {
+	oamd.accessToken = accessToken
+	return oamd
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oamd *OfficeAccountMenuDelete) SetAccessTokenSource(iat IAccessToken) *OfficeAccountMenuDelete {
+	oamd.iat = iat
+	return oamd
+}
+
+// Validate checks if the operation is valid.
+func (oamd *OfficeAccountMenuDelete) Validate() error {
+	if oamd.accessToken == "" && oamd.iat == nil {
+		return fmt.Errorf("missing required fields: %v", []string{"access_token"})
+	}
+	return nil
+}
+
+// Do Do
+func (oamd *OfficeAccountMenuDelete) Do(ctx context.Context) (*OfficeAccountMenuDeleteResponse, error) {
+	// Check pre-conditions
+	if err := oamd.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuDelete.Do")
+	}
+	res, err := oamd.client.performTokenRequest(ctx, oamd.accessToken, oamd.iat, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   url.Values{},
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountMenuDeleteEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuDelete.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountMenuDeleteResponse)
+	if err := oamd.client.decodeResponse(OfficeAccountMenuDeleteEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountMenuDelete.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountMenuDeleteResponse OfficeAccountMenuDeleteResponse
+type OfficeAccountMenuDeleteResponse struct {
+	CommonError
+}
+
+// -- conditional menus --
+
+// OfficeAccountMenuAddConditional 创建个性化菜单，需要先创建默认菜单
+type OfficeAccountMenuAddConditional struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	buttons     []OfficeAccountMenuButton
+	matchRule   *OfficeAccountMenuMatchRule
+}
+
+// NewOfficeAccountMenuAddConditional return instance of OfficeAccountMenuAddConditional
+func NewOfficeAccountMenuAddConditional(client *Client) *OfficeAccountMenuAddConditional {
+	oama := &OfficeAccountMenuAddConditional{
+		client: client,
+	}
+	return oama
+}
+
+// SetAccessToken SetAccessToken
+func (oama *OfficeAccountMenuAddConditional) SetAccessToken(accessToken string) *OfficeAccountMenuAddConditional {
+	oama.accessToken = accessToken
+	return oama
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oama *OfficeAccountMenuAddConditional) SetAccessTokenSource(iat IAccessToken) *OfficeAccountMenuAddConditional {
+	oama.iat = iat
+	return oama
+}
+
+// SetButtons 一级菜单，最多 3 个，每个最多 5 个二级菜单
+func (oama *OfficeAccountMenuAddConditional) SetButtons(buttons ...OfficeAccountMenuButton) *OfficeAccountMenuAddConditional {
+	oama.buttons = buttons
+	return oama
+}
+
+// SetMatchRule SetMatchRule
+func (oama *OfficeAccountMenuAddConditional) SetMatchRule(matchRule *OfficeAccountMenuMatchRule) *OfficeAccountMenuAddConditional {
+	oama.matchRule = matchRule
+	return oama
+}
+
+// Validate checks if the operation is valid.
+func (oama *OfficeAccountMenuAddConditional) Validate() error {
+	if oama.accessToken == "" && oama.iat == nil {
+		return fmt.Errorf("missing required fields: %v", []string{"access_token"})
+	}
+	if err := oama.matchRule.Validate(); err != nil {
+		return err
+	}
+	return validateMenuButtons(oama.buttons)
+}
+
+// Do Do
+func (oama *OfficeAccountMenuAddConditional) Do(ctx context.Context) (*OfficeAccountMenuAddConditionalResponse, error) {
+	// Check pre-conditions
+	if err := oama.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuAddConditional.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]interface{}{
+		"button":    oama.buttons,
+		"matchrule": oama.matchRule,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuAddConditional.Do")
+	}
+	res, err := oama.client.performTokenRequest(ctx, oama.accessToken, oama.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountMenuAddConditionalEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuAddConditional.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountMenuAddConditionalResponse)
+	if err := oama.client.decodeResponse(OfficeAccountMenuAddConditionalEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountMenuAddConditional.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountMenuAddConditionalResponse OfficeAccountMenuAddConditionalResponse
+type OfficeAccountMenuAddConditionalResponse struct {
+	CommonError
+	MenuID string `json:"menuid"`
+}
+
+// OfficeAccountMenuDelConditional 删除个性化菜单
+type OfficeAccountMenuDelConditional struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	menuID      string
+}
+
+// NewOfficeAccountMenuDelConditional return instance of OfficeAccountMenuDelConditional
+func NewOfficeAccountMenuDelConditional(client *Client) *OfficeAccountMenuDelConditional {
+	oamd := &OfficeAccountMenuDelConditional{
+		client: client,
+	}
+	return oamd
+}
+
+// SetAccessToken SetAccessToken
+func (oamd *OfficeAccountMenuDelConditional) SetAccessToken(accessToken string) *OfficeAccountMenuDelConditional {
+	oamd.accessToken = accessToken
+	return oamd
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oamd *OfficeAccountMenuDelConditional) SetAccessTokenSource(iat IAccessToken) *OfficeAccountMenuDelConditional {
+	oamd.iat = iat
+	return oamd
+}
+
+// SetMenuID 创建个性化菜单时返回的 menuid
+func (oamd *OfficeAccountMenuDelConditional) SetMenuID(menuID string) *OfficeAccountMenuDelConditional {
+	oamd.menuID = menuID
+	return oamd
+}
+
+// Validate checks if the operation is valid.
+func (oamd *OfficeAccountMenuDelConditional) Validate() error {
+	var invalid []string
+	if oamd.accessToken == "" && oamd.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if oamd.menuID == "" {
+		invalid = append(invalid, "menuid")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (oamd *OfficeAccountMenuDelConditional) Do(ctx context.Context) (*OfficeAccountMenuDelConditionalResponse, error) {
+	// Check pre-conditions
+	if err := oamd.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuDelConditional.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]string{
+		"menuid": oamd.menuID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuDelConditional.Do")
+	}
+	res, err := oamd.client.performTokenRequest(ctx, oamd.accessToken, oamd.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountMenuDelConditionalEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuDelConditional.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountMenuDelConditionalResponse)
+	if err := oamd.client.decodeResponse(OfficeAccountMenuDelConditionalEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountMenuDelConditional.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountMenuDelConditionalResponse OfficeAccountMenuDelConditionalResponse
+type OfficeAccountMenuDelConditionalResponse struct {
+	CommonError
+}
+
+// OfficeAccountMenuTryMatch 测试个性化菜单匹配结果
+type OfficeAccountMenuTryMatch struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	userID      string
+}
+
+// NewOfficeAccountMenuTryMatch return instance of OfficeAccountMenuTryMatch
+func NewOfficeAccountMenuTryMatch(client *Client) *OfficeAccountMenuTryMatch {
+	oamt := &OfficeAccountMenuTryMatch{
+		client: client,
+	}
+	return oamt
+}
+
+// SetAccessToken SetAccessToken
+func (oamt *OfficeAccountMenuTryMatch) SetAccessToken(accessToken string) *OfficeAccountMenuTryMatch {
+	oamt.accessToken = accessToken
+	return oamt
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oamt *OfficeAccountMenuTryMatch) SetAccessTokenSource(iat IAccessToken) *OfficeAccountMenuTryMatch {
+	oamt.iat = iat
+	return oamt
+}
+
+// SetUserID 粉丝的 OpenID 或微信号
+func (oamt *OfficeAccountMenuTryMatch) SetUserID(userID string) *OfficeAccountMenuTryMatch {
+	oamt.userID = userID
+	return oamt
+}
+
+// Validate checks if the operation is valid.
+func (oamt *OfficeAccountMenuTryMatch) Validate() error {
+	var invalid []string
+	if oamt.accessToken == "" && oamt.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if oamt.userID == "" {
+		invalid = append(invalid, "user_id")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (oamt *OfficeAccountMenuTryMatch) Do(ctx context.Context) (*OfficeAccountMenuTryMatchResponse, error) {
+	// Check pre-conditions
+	if err := oamt.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuTryMatch.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]string{
+		"user_id": oamt.userID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuTryMatch.Do")
+	}
+	res, err := oamt.client.performTokenRequest(ctx, oamt.accessToken, oamt.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountMenuTryMatchEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountMenuTryMatch.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountMenuTryMatchResponse)
+	if err := oamt.client.decodeResponse(OfficeAccountMenuTryMatchEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountMenuTryMatch.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountMenuTryMatchResponse OfficeAccountMenuTryMatchResponse
+type OfficeAccountMenuTryMatchResponse struct {
+	CommonError
+	Buttons []OfficeAccountMenuButton `json:"button"`
+}
@@ -0,0 +1,82 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateMenuButtons(t *testing.T) {
+	click := OfficeAccountMenuButton{Type: MenuButtonClick, Name: "今日歌曲", Key: "V1001_TODAY_MUSIC"}
+	tests := []struct {
+		name    string
+		buttons []OfficeAccountMenuButton
+		wantErr bool
+	}{
+		{name: "ok", buttons: []OfficeAccountMenuButton{click, {Name: "菜单", SubButtons: []OfficeAccountMenuButton{
+			{Type: MenuButtonView, Name: "搜索", URL: "http://www.soso.com/"},
+			{Type: MenuButtonMiniProgram, Name: "wxa", URL: "http://mp.weixin.qq.com", AppID: "wx286b93c14bbf93aa", PagePath: "pages/lunar/index"},
+		}}}},
+		{name: "empty", wantErr: true},
+		{name: "too many buttons", buttons: []OfficeAccountMenuButton{click, click, click, click}, wantErr: true},
+		{name: "too many sub buttons", buttons: []OfficeAccountMenuButton{{Name: "菜单", SubButtons: []OfficeAccountMenuButton{click, click, click, click, click, click}}}, wantErr: true},
+		{name: "nested too deep", buttons: []OfficeAccountMenuButton{{Name: "菜单", SubButtons: []OfficeAccountMenuButton{{Name: "子菜单", SubButtons: []OfficeAccountMenuButton{click}}}}}, wantErr: true},
+		{name: "name too long", buttons: []OfficeAccountMenuButton{{Type: MenuButtonClick, Name: "一二三四五六", Key: "key"}}, wantErr: true},
+		{name: "long sub name", buttons: []OfficeAccountMenuButton{{Name: "菜单", SubButtons: []OfficeAccountMenuButton{{Type: MenuButtonClick, Name: "一二三四五六", Key: "key"}}}}},
+		{name: "missing key", buttons: []OfficeAccountMenuButton{{Type: MenuButtonScancodePush, Name: "扫码"}}, wantErr: true},
+		{name: "missing pagepath", buttons: []OfficeAccountMenuButton{{Type: MenuButtonMiniProgram, Name: "wxa", URL: "http://mp.weixin.qq.com", AppID: "appid"}}, wantErr: true},
+		{name: "unknown type", buttons: []OfficeAccountMenuButton{{Type: "text", Name: "文本"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateMenuButtons(tt.buttons); (err != nil) != tt.wantErr {
+				t.Log(err)
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestOfficeAccountMenuAddConditional(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var req struct {
+			Buttons   []OfficeAccountMenuButton   `json:"button"`
+			MatchRule *OfficeAccountMenuMatchRule `json:"matchrule"`
+		}
+		if r.URL.Path != "/"+OfficeAccountMenuAddConditionalEndpoint || r.URL.Query().Get("access_token") != "token" ||
+			json.Unmarshal(body, &req) != nil || len(req.Buttons) != 1 || req.MatchRule == nil || req.MatchRule.TagID != "2" {
+			w.Write([]byte(`{"errcode":40001,"errmsg":"invalid"}`))
+			return
+		}
+		w.Write([]byte(`{"menuid":"208379533"}`))
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(OfficeAccountHost, ts.URL))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	button := OfficeAccountMenuButton{Type: MenuButtonClick, Name: "今日歌曲", Key: "V1001_TODAY_MUSIC"}
+	if _, err := client.OfficeAccountMenuAddConditional().SetAccessToken("token").SetButtons(button).Do(ctx); err == nil || !strings.Contains(err.Error(), "matchrule") {
+		t.Log(err)
+		t.FailNow()
+	}
+	ret, err := client.OfficeAccountMenuAddConditional().
+		SetAccessToken("token").
+		SetButtons(button).
+		SetMatchRule(&OfficeAccountMenuMatchRule{TagID: "2"}).
+		Do(ctx)
+	if err != nil || ret.MenuID != "208379533" {
+		t.Log(ret, err)
+		t.FailNow()
+	}
+}
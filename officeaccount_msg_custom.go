@@ -123,7 +123,7 @@ func (mpum *OACustomMessage) Validate() error {
 
 // BaseURI BaseURI
 func (mpum *OACustomMessage) BaseURI() string {
-	return MiniProgramBaseHost
+	return OfficeAccountHost
 }
 
 // Endpoint Endpoint
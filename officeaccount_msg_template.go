@@ -0,0 +1,96 @@
+package wechat
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+const (
+	// OATemplateMessageEndpoint https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Template_Message_Interface.html
+	OATemplateMessageEndpoint = "cgi-bin/message/template/send"
+)
+
+// colors of template data are #RRGGBB
+var oaTemplateColor = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// OATemplateMessage 实现 IBasicMessage 接口
+type OATemplateMessage struct {
+	MsgBody   *OATemplateMessageBody
+	MsgParams url.Values
+}
+
+// OATemplateMessageBody 消息体，URL 和 Miniprogram 都设置时优先跳转小程序，
+// 客户端不支持小程序时跳转 URL。ClientMsgID 用于防重入，同一 ClientMsgID 的
+// 消息只发送一次
+type OATemplateMessageBody struct {
+	Touser      string                    `json:"touser"`
+	TemplateID  string                    `json:"template_id"`
+	URL         string                    `json:"url,omitempty"`
+	Miniprogram *OATemplateMiniprogram    `json:"miniprogram,omitempty"`
+	ClientMsgID string                    `json:"client_msg_id,omitempty"`
+	Data        map[string]OATemplateData `json:"data"`
+}
+
+// OATemplateMiniprogram 跳转的小程序，小程序必须与公众号关联
+type OATemplateMiniprogram struct {
+	Appid    string `json:"appid"`
+	Pagepath string `json:"pagepath,omitempty"`
+}
+
+// OATemplateData 模板数据，Color 为 #RRGGBB，不填默认黑色
+type OATemplateData struct {
+	Value string `json:"value"`
+	Color string `json:"color,omitempty"`
+}
+
+// NewOATemplateMessage 模板消息
+func NewOATemplateMessage(sm *OATemplateMessage) *OATemplateMessage {
+	return sm
+}
+
+// Body Body
+func (mpum *OATemplateMessage) Body() interface{} {
+	return mpum.MsgBody
+}
+
+// Validate Validate
+func (mpum *OATemplateMessage) Validate() error {
+	if mpum.MsgBody == nil {
+		return errors.New("body is nil")
+	}
+	if mpum.MsgBody.Touser == "" {
+		return errors.New("接收人 openid 为空")
+	}
+	if mpum.MsgBody.TemplateID == "" {
+		return errors.New("模板 id 为空")
+	}
+	if mpum.MsgBody.Miniprogram != nil && mpum.MsgBody.Miniprogram.Appid == "" {
+		return errors.New("小程序 appid 为空")
+	}
+	for key, data := range mpum.MsgBody.Data {
+		if data.Color != "" && !oaTemplateColor.MatchString(data.Color) {
+			return fmt.Errorf("not allowed color %q of %q", data.Color, key)
+		}
+	}
+	if mpum.MsgParams == nil {
+		mpum.MsgParams = url.Values{}
+	}
+	return nil
+}
+
+// BaseURI BaseURI
+func (mpum *OATemplateMessage) BaseURI() string {
+	return OfficeAccountHost
+}
+
+// Endpoint Endpoint
+func (mpum *OATemplateMessage) Endpoint() string {
+	return OATemplateMessageEndpoint
+}
+
+// Params Params
+func (mpum *OATemplateMessage) Params() url.Values {
+	return mpum.MsgParams
+}
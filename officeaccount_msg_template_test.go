@@ -0,0 +1,68 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOATemplateMessage(t *testing.T) {
+	var got OATemplateMessageBody
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/"+OfficeAccountAccessTokenEndpoint {
+			w.Write([]byte(`{"access_token":"token","expires_in":7200}`))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.URL.Path != "/"+OATemplateMessageEndpoint || json.Unmarshal(body, &got) != nil {
+			w.Write([]byte(`{"errcode":40037,"errmsg":"invalid template_id"}`))
+			return
+		}
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok","msgid":200228332}`))
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(OfficeAccountHost, ts.URL))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	iat := client.OfficeAccountAccessToken().SetAppID("appid").SetSecret("secret")
+
+	tests := []struct {
+		name    string
+		body    *OATemplateMessageBody
+		wantErr bool
+	}{
+		{name: "no template", body: &OATemplateMessageBody{Touser: "openid"}, wantErr: true},
+		{name: "no appid", body: &OATemplateMessageBody{Touser: "openid", TemplateID: "tid", Miniprogram: &OATemplateMiniprogram{Pagepath: "index"}}, wantErr: true},
+		{name: "bad color", body: &OATemplateMessageBody{Touser: "openid", TemplateID: "tid", Data: map[string]OATemplateData{"first": {Value: "hi", Color: "red"}}}, wantErr: true},
+		{name: "ok", body: &OATemplateMessageBody{
+			Touser:      "openid",
+			TemplateID:  "tid",
+			URL:         "http://weixin.qq.com/download",
+			Miniprogram: &OATemplateMiniprogram{Appid: "xiaochengxuappid12345", Pagepath: "index?foo=bar"},
+			ClientMsgID: "MSG_000001",
+			Data:        map[string]OATemplateData{"keyword1": {Value: "巧克力", Color: "#173177"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = OATemplateMessageBody{}
+			err := client.BasicMessage(iat, NewOATemplateMessage(&OATemplateMessage{MsgBody: tt.body})).Send(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Log(err)
+				t.FailNow()
+			}
+			if !tt.wantErr && (got.ClientMsgID != "MSG_000001" || got.Miniprogram == nil || got.Data["keyword1"].Color != "#173177") {
+				t.Log(got)
+				t.FailNow()
+			}
+		})
+	}
+}
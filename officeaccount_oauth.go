@@ -0,0 +1,733 @@
+package wechat
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Endpoint
+const (
+	OfficeAccountOAuthAuthorizeEndpoint    = "connect/oauth2/authorize"
+	OfficeAccountOAuthAccessTokenEndpoint  = "sns/oauth2/access_token"
+	OfficeAccountOAuthRefreshTokenEndpoint = "sns/oauth2/refresh_token"
+	OfficeAccountOAuthUserInfoEndpoint     = "sns/userinfo"
+	OfficeAccountOAuthAuthEndpoint         = "sns/auth"
+)
+
+// scopes of office account web authorization
+const (
+	ScopeSnsapiBase     = "snsapi_base"     // 静默授权，只能获取 openid
+	ScopeSnsapiUserInfo = "snsapi_userinfo" // 需要用户确认，可以获取用户信息
+)
+
+// const of office account oauth
+const (
+	// OfficeAccountOAuthRefreshTokenLifetime is how long a refresh_token is
+	// valid, user tokens are cached as long.
+	OfficeAccountOAuthRefreshTokenLifetime = 30 * 24 * time.Hour
+
+	oauthStateCookie = "wechat_oauth_state"
+	oauthSafeSeconds = 60
+)
+
+// OfficeAccountOAuthToken 网页授权 access_token，与基础 access_token 不同
+type OfficeAccountOAuthToken struct {
+	AccessToken    string `json:"access_token"`
+	ExpiresIn      int64  `json:"expires_in"`
+	RefreshToken   string `json:"refresh_token"`
+	OpenID         string `json:"openid"`
+	Scope          string `json:"scope"`
+	IsSnapshotUser int64  `json:"is_snapshotuser,omitempty"`
+	UnionID        string `json:"unionid,omitempty"`
+	CreatedAt      int64  `json:"created_at,omitempty"` // set by OfficeAccountOAuthTokenStore
+}
+
+// Expired returns true if the access token is expired or about to expire.
+func (t *OfficeAccountOAuthToken) Expired() bool {
+	return time.Now().Unix() >= t.CreatedAt+t.ExpiresIn-oauthSafeSeconds
+}
+
+// OfficeAccountOAuthTokenResponse OfficeAccountOAuthTokenResponse
+type OfficeAccountOAuthTokenResponse struct {
+	CommonError
+	OfficeAccountOAuthToken
+}
+
+// -- OfficeAccountOAuthURL --
+
+// OfficeAccountOAuthURL 拼装用户同意授权的 url
+type OfficeAccountOAuthURL struct {
+	client *Client
+
+	appid       string
+	redirectURI string
+	scope       string
+	state       string
+}
+
+// NewOfficeAccountOAuthURL return instance of OfficeAccountOAuthURL
+func NewOfficeAccountOAuthURL(client *Client) *OfficeAccountOAuthURL {
+	oaou := &OfficeAccountOAuthURL{
+		client: client,
+		scope:  ScopeSnsapiBase,
+	}
+	return oaou
+}
+
+// SetAppID SetAppID
+func (oaou *OfficeAccountOAuthURL) SetAppID(appid string) *OfficeAccountOAuthURL {
+	oaou.appid = appid
+	return oaou
+}
+
+// SetRedirectURI 授权后重定向的回调链接地址
+func (oaou *OfficeAccountOAuthURL) SetRedirectURI(redirectURI string) *OfficeAccountOAuthURL {
+	oaou.redirectURI = redirectURI
+	return oaou
+}
+
+// SetScope ScopeSnsapiBase (default) or ScopeSnsapiUserInfo
+func (oaou *OfficeAccountOAuthURL) SetScope(scope string) *OfficeAccountOAuthURL {
+	oaou.scope = scope
+	return oaou
+}
+
+// SetState 重定向后会带上 state 参数，最多 128 字节
+func (oaou *OfficeAccountOAuthURL) SetState(state string) *OfficeAccountOAuthURL {
+	oaou.state = state
+	return oaou
+}
+
+// Validate checks if the operation is valid.
+func (oaou *OfficeAccountOAuthURL) Validate() error {
+	var invalid []string
+	if oaou.appid == "" {
+		invalid = append(invalid, "appid")
+	}
+	if oaou.redirectURI == "" {
+		invalid = append(invalid, "redirect_uri")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	if oaou.scope != ScopeSnsapiBase && oaou.scope != ScopeSnsapiUserInfo {
+		return fmt.Errorf("not allowed scope %q", oaou.scope)
+	}
+	if len(oaou.state) > 128 {
+		return fmt.Errorf("state is longer than 128 bytes")
+	}
+	return nil
+}
+
+// URL returns the url to redirect the user to.
+func (oaou *OfficeAccountOAuthURL) URL() (string, error) {
+	if err := oaou.Validate(); err != nil {
+		return "", errors.Wrap(err, "OfficeAccountOAuthURL.URL")
+	}
+	// wechat expects the params in this order
+	params := fmt.Sprintf("appid=%s&redirect_uri=%s&response_type=code&scope=%s&state=%s",
+		url.QueryEscape(oaou.appid), url.QueryEscape(oaou.redirectURI), url.QueryEscape(oaou.scope), url.QueryEscape(oaou.state))
+	return fmt.Sprintf("%s/%s?%s#wechat_redirect", oaou.client.BaseURL(OpenPlatformHost), OfficeAccountOAuthAuthorizeEndpoint, params), nil
+}
+
+// -- OfficeAccountOAuthAccessToken --
+
+// OfficeAccountOAuthAccessToken 通过 code 换取网页授权 access_token
+type OfficeAccountOAuthAccessToken struct {
+	client *Client
+
+	appid  string
+	secret string
+	code   string
+}
+
+// NewOfficeAccountOAuthAccessToken return instance of OfficeAccountOAuthAccessToken
+func NewOfficeAccountOAuthAccessToken(client *Client) *OfficeAccountOAuthAccessToken {
+	oaoat := &OfficeAccountOAuthAccessToken{
+		client: client,
+	}
+	return oaoat
+}
+
+// SetAppID SetAppID
+func (oaoat *OfficeAccountOAuthAccessToken) SetAppID(appid string) *OfficeAccountOAuthAccessToken {
+	oaoat.appid = appid
+	return oaoat
+}
+
+// SetSecret SetSecret
+func (oaoat *OfficeAccountOAuthAccessToken) SetSecret(secret string) *OfficeAccountOAuthAccessToken {
+	oaoat.secret = secret
+	return oaoat
+}
+
+// SetCode SetCode
+func (oaoat *OfficeAccountOAuthAccessToken) SetCode(code string) *OfficeAccountOAuthAccessToken {
+	oaoat.code = code
+	return oaoat
+}
+
+// Validate checks if the operation is valid.
+func (oaoat *OfficeAccountOAuthAccessToken) Validate() error {
+	var invalid []string
+	if oaoat.appid == "" {
+		invalid = append(invalid, "appid")
+	}
+	if oaoat.secret == "" {
+		invalid = append(invalid, "secret")
+	}
+	if oaoat.code == "" {
+		invalid = append(invalid, "code")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (oaoat *OfficeAccountOAuthAccessToken) Do(ctx context.Context) (*OfficeAccountOAuthTokenResponse, error) {
+	// Check pre-conditions
+	if err := oaoat.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountOAuthAccessToken.Do")
+	}
+	// url params
+	params := url.Values{}
+	params.Set("appid", oaoat.appid)
+	params.Set("secret", oaoat.secret)
+	params.Set("code", oaoat.code)
+	params.Set("grant_type", "authorization_code")
+	// PerformRequest
+	res, err := oaoat.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   params,
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountOAuthAccessTokenEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountOAuthAccessToken.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountOAuthTokenResponse)
+	if err := oaoat.client.decodeResponse(OfficeAccountOAuthAccessTokenEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountOAuthAccessToken.Do")
+	}
+	return ret, nil
+}
+
+// -- OfficeAccountOAuthRefreshToken --
+
+// OfficeAccountOAuthRefreshToken 刷新网页授权 access_token
+type OfficeAccountOAuthRefreshToken struct {
+	client *Client
+
+	appid        string
+	refreshToken string
+}
+
+// NewOfficeAccountOAuthRefreshToken return instance of OfficeAccountOAuthRefreshToken
+func NewOfficeAccountOAuthRefreshToken(client *Client) *OfficeAccountOAuthRefreshToken {
+	oaort := &OfficeAccountOAuthRefreshToken{
+		client: client,
+	}
+	return oaort
+}
+
+// SetAppID SetAppID
+func (oaort *OfficeAccountOAuthRefreshToken) SetAppID(appid string) *OfficeAccountOAuthRefreshToken {
+	oaort.appid = appid
+	return oaort
+}
+
+// SetRefreshToken SetRefreshToken
+func (oaort *OfficeAccountOAuthRefreshToken) SetRefreshToken(refreshToken string) *OfficeAccountOAuthRefreshToken {
+	oaort.refreshToken = refreshToken
+	return oaort
+}
+
+// Validate checks if the operation is valid.
+func (oaort *OfficeAccountOAuthRefreshToken) Validate() error {
+	var invalid []string
+	if oaort.appid == "" {
+		invalid = append(invalid, "appid")
+	}
+	if oaort.refreshToken == "" {
+		invalid = append(invalid, "refresh_token")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (oaort *OfficeAccountOAuthRefreshToken) Do(ctx context.Context) (*OfficeAccountOAuthTokenResponse, error) {
+	// Check pre-conditions
+	if err := oaort.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountOAuthRefreshToken.Do")
+	}
+	// url params
+	params := url.Values{}
+	params.Set("appid", oaort.appid)
+	params.Set("grant_type", "refresh_token")
+	params.Set("refresh_token", oaort.refreshToken)
+	// PerformRequest
+	res, err := oaort.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   params,
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountOAuthRefreshTokenEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountOAuthRefreshToken.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountOAuthTokenResponse)
+	if err := oaort.client.decodeResponse(OfficeAccountOAuthRefreshTokenEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountOAuthRefreshToken.Do")
+	}
+	return ret, nil
+}
+
+// -- OfficeAccountOAuthUserInfo --
+
+// OfficeAccountOAuthUserInfo 拉取用户信息，需 scope 为 snsapi_userinfo
+type OfficeAccountOAuthUserInfo struct {
+	client *Client
+
+	accessToken string
+	openid      string
+	lang        string
+}
+
+// NewOfficeAccountOAuthUserInfo return instance of OfficeAccountOAuthUserInfo
+func NewOfficeAccountOAuthUserInfo(client *Client) *OfficeAccountOAuthUserInfo {
+	oaoui := &OfficeAccountOAuthUserInfo{
+		client: client,
+		lang:   "zh_CN",
+	}
+	return oaoui
+}
+
+// SetAccessToken 网页授权 access_token
+func (oaoui *OfficeAccountOAuthUserInfo) SetAccessToken(accessToken string) *OfficeAccountOAuthUserInfo {
+	oaoui.accessToken = accessToken
+	return oaoui
+}
+
+// SetOpenID SetOpenID
+func (oaoui *OfficeAccountOAuthUserInfo) SetOpenID(openid string) *OfficeAccountOAuthUserInfo {
+	oaoui.openid = openid
+	return oaoui
+}
+
+// SetLang zh_CN (default), zh_TW or en
+func (oaoui *OfficeAccountOAuthUserInfo) SetLang(lang string) *OfficeAccountOAuthUserInfo {
+	oaoui.lang = lang
+	return oaoui
+}
+
+// Validate checks if the operation is valid.
+func (oaoui *OfficeAccountOAuthUserInfo) Validate() error {
+	var invalid []string
+	if oaoui.accessToken == "" {
+		invalid = append(invalid, "access_token")
+	}
+	if oaoui.openid == "" {
+		invalid = append(invalid, "openid")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (oaoui *OfficeAccountOAuthUserInfo) Do(ctx context.Context) (*OfficeAccountOAuthUserInfoResponse, error) {
+	// Check pre-conditions
+	if err := oaoui.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountOAuthUserInfo.Do")
+	}
+	// url params
+	params := url.Values{}
+	params.Set("access_token", oaoui.accessToken)
+	params.Set("openid", oaoui.openid)
+	params.Set("lang", oaoui.lang)
+	// PerformRequest
+	res, err := oaoui.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   params,
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountOAuthUserInfoEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountOAuthUserInfo.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountOAuthUserInfoResponse)
+	if err := oaoui.client.decodeResponse(OfficeAccountOAuthUserInfoEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountOAuthUserInfo.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountOAuthUserInfoResponse OfficeAccountOAuthUserInfoResponse
+type OfficeAccountOAuthUserInfoResponse struct {
+	CommonError
+	OpenID     string   `json:"openid"`
+	Nickname   string   `json:"nickname"`
+	Sex        int64    `json:"sex"`
+	Province   string   `json:"province"`
+	City       string   `json:"city"`
+	Country    string   `json:"country"`
+	HeadImgURL string   `json:"headimgurl"`
+	Privilege  []string `json:"privilege"`
+	UnionID    string   `json:"unionid"`
+}
+
+// -- OfficeAccountOAuthAuth --
+
+// OfficeAccountOAuthAuth 检验网页授权 access_token 是否有效
+type OfficeAccountOAuthAuth struct {
+	client *Client
+
+	accessToken string
+	openid      string
+}
+
+// NewOfficeAccountOAuthAuth return instance of OfficeAccountOAuthAuth
+func NewOfficeAccountOAuthAuth(client *Client) *OfficeAccountOAuthAuth {
+	oaoa := &OfficeAccountOAuthAuth{
+		client: client,
+	}
+	return oaoa
+}
+
+// SetAccessToken 网页授权 access_token
+func (oaoa *OfficeAccountOAuthAuth) SetAccessToken(accessToken string) *OfficeAccountOAuthAuth {
+	oaoa.accessToken = accessToken
+	return oaoa
+}
+
+// SetOpenID SetOpenID
+func (oaoa *OfficeAccountOAuthAuth) SetOpenID(openid string) *OfficeAccountOAuthAuth {
+	oaoa.openid = openid
+	return oaoa
+}
+
+// Validate checks if the operation is valid.
+func (oaoa *OfficeAccountOAuthAuth) Validate() error {
+	var invalid []string
+	if oaoa.accessToken == "" {
+		invalid = append(invalid, "access_token")
+	}
+	if oaoa.openid == "" {
+		invalid = append(invalid, "openid")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do returns an *APIError if the token is invalid.
+func (oaoa *OfficeAccountOAuthAuth) Do(ctx context.Context) (*CommonError, error) {
+	// Check pre-conditions
+	if err := oaoa.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountOAuthAuth.Do")
+	}
+	// url params
+	params := url.Values{}
+	params.Set("access_token", oaoa.accessToken)
+	params.Set("openid", oaoa.openid)
+	// PerformRequest
+	res, err := oaoa.client.PerformRequest(ctx, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   params,
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountOAuthAuthEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountOAuthAuth.Do")
+	}
+	// Return operation response
+	ret := new(CommonError)
+	if err := oaoa.client.decodeResponse(OfficeAccountOAuthAuthEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountOAuthAuth.Do")
+	}
+	return ret, nil
+}
+
+// -- OfficeAccountOAuthTokenStore --
+
+// OfficeAccountOAuthTokenStore 网页授权 access_token 的存储，使用 Client 的
+// Cache，按 openid 保存。Tokens are refreshed with their refresh_token when
+// they expire.
+type OfficeAccountOAuthTokenStore struct {
+	client *Client
+	appid  string
+}
+
+// NewOfficeAccountOAuthTokenStore return instance of OfficeAccountOAuthTokenStore
+func NewOfficeAccountOAuthTokenStore(client *Client, appid string) *OfficeAccountOAuthTokenStore {
+	return &OfficeAccountOAuthTokenStore{
+		client: client,
+		appid:  appid,
+	}
+}
+
+func (oats *OfficeAccountOAuthTokenStore) cacheKey(openid string) string {
+	return MD5Sum(fmt.Sprintf("%soauth_%s_%s", cachekeyPrefix, oats.appid, openid))
+}
+
+// Save caches token for its openid, CreatedAt is set to now if empty.
+func (oats *OfficeAccountOAuthTokenStore) Save(ctx context.Context, token *OfficeAccountOAuthToken) error {
+	if token.OpenID == "" {
+		return errors.New("OfficeAccountOAuthTokenStore.Save: missing openid")
+	}
+	if token.CreatedAt == 0 {
+		token.CreatedAt = time.Now().Unix()
+	}
+	if err := oats.client.cache.Set(ctx, oats.cacheKey(token.OpenID), token, OfficeAccountOAuthRefreshTokenLifetime); err != nil {
+		return errors.Wrap(err, "OfficeAccountOAuthTokenStore.Save")
+	}
+	return nil
+}
+
+// Load returns the cached token of openid as it is, ErrCacheKeyNotExist if
+// there is none.
+func (oats *OfficeAccountOAuthTokenStore) Load(ctx context.Context, openid string) (*OfficeAccountOAuthToken, error) {
+	value, err := oats.client.cache.Get(ctx, oats.cacheKey(openid))
+	if err != nil {
+		return nil, err
+	}
+	token := new(OfficeAccountOAuthToken)
+	if err := UnmarshalCacheValue(value, token); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountOAuthTokenStore.Load")
+	}
+	return token, nil
+}
+
+// Delete removes the cached token of openid.
+func (oats *OfficeAccountOAuthTokenStore) Delete(ctx context.Context, openid string) error {
+	return oats.client.cache.Delete(ctx, oats.cacheKey(openid))
+}
+
+// Token returns a valid token of openid, refreshing the cached one if it
+// is expired. The user has to authorize again if this fails.
+func (oats *OfficeAccountOAuthTokenStore) Token(ctx context.Context, openid string) (*OfficeAccountOAuthToken, error) {
+	token, err := oats.Load(ctx, openid)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Expired() {
+		return token, nil
+	}
+	v, _, err := oats.client.tokenFlight.Do(ctx, oats.cacheKey(openid), func() (interface{}, error) {
+		res, err := NewOfficeAccountOAuthRefreshToken(oats.client).
+			SetAppID(oats.appid).
+			SetRefreshToken(token.RefreshToken).
+			Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		fresh := res.OfficeAccountOAuthToken
+		fresh.CreatedAt = 0
+		if err := oats.Save(ctx, &fresh); err != nil {
+			return nil, err
+		}
+		return &fresh, nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountOAuthTokenStore.Token")
+	}
+	return v.(*OfficeAccountOAuthToken), nil
+}
+
+// -- OfficeAccountOAuthHandler --
+
+// OfficeAccountOAuthHandler is an http.Handler doing the web authorization:
+// requests without code are redirected to wechat, wechat redirects back
+// with a code, which is exchanged for a token. The token is saved to the
+// OfficeAccountOAuthTokenStore and passed to the success handler.
+type OfficeAccountOAuthHandler struct {
+	client *Client
+
+	appid       string
+	secret      string
+	scope       string
+	redirectURI string
+	onSuccess   func(w http.ResponseWriter, r *http.Request, token *OfficeAccountOAuthToken)
+	onError     func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// NewOfficeAccountOAuthHandler return instance of OfficeAccountOAuthHandler
+func NewOfficeAccountOAuthHandler(client *Client) *OfficeAccountOAuthHandler {
+	oaoh := &OfficeAccountOAuthHandler{
+		client: client,
+		scope:  ScopeSnsapiBase,
+		onError: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, "wechat authorization failed", http.StatusBadRequest)
+		},
+	}
+	return oaoh
+}
+
+// SetAppID SetAppID
+func (oaoh *OfficeAccountOAuthHandler) SetAppID(appid string) *OfficeAccountOAuthHandler {
+	oaoh.appid = appid
+	return oaoh
+}
+
+// SetSecret SetSecret
+func (oaoh *OfficeAccountOAuthHandler) SetSecret(secret string) *OfficeAccountOAuthHandler {
+	oaoh.secret = secret
+	return oaoh
+}
+
+// SetScope ScopeSnsapiBase (default) or ScopeSnsapiUserInfo
+func (oaoh *OfficeAccountOAuthHandler) SetScope(scope string) *OfficeAccountOAuthHandler {
+	oaoh.scope = scope
+	return oaoh
+}
+
+// SetRedirectURI sets the url wechat redirects back to, which must be
+// served by the handler. It defaults to the url of the request, which may
+// be wrong behind proxies.
+func (oaoh *OfficeAccountOAuthHandler) SetRedirectURI(redirectURI string) *OfficeAccountOAuthHandler {
+	oaoh.redirectURI = redirectURI
+	return oaoh
+}
+
+// SetSuccessHandler sets what to do with the token, e.g. start a session
+// and redirect to the page.
+func (oaoh *OfficeAccountOAuthHandler) SetSuccessHandler(fn func(w http.ResponseWriter, r *http.Request, token *OfficeAccountOAuthToken)) *OfficeAccountOAuthHandler {
+	oaoh.onSuccess = fn
+	return oaoh
+}
+
+// SetErrorHandler sets what to do if the authorization failed or the user
+// refused it. It responds with 400 by default.
+func (oaoh *OfficeAccountOAuthHandler) SetErrorHandler(fn func(w http.ResponseWriter, r *http.Request, err error)) *OfficeAccountOAuthHandler {
+	oaoh.onError = fn
+	return oaoh
+}
+
+// Validate checks if the handler is valid.
+func (oaoh *OfficeAccountOAuthHandler) Validate() error {
+	var invalid []string
+	if oaoh.appid == "" {
+		invalid = append(invalid, "appid")
+	}
+	if oaoh.secret == "" {
+		invalid = append(invalid, "secret")
+	}
+	if oaoh.onSuccess == nil {
+		invalid = append(invalid, "success handler")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Store returns the token store of the handler.
+func (oaoh *OfficeAccountOAuthHandler) Store() *OfficeAccountOAuthTokenStore {
+	return NewOfficeAccountOAuthTokenStore(oaoh.client, oaoh.appid)
+}
+
+// ServeHTTP ServeHTTP
+func (oaoh *OfficeAccountOAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := oaoh.Validate(); err != nil {
+		oaoh.client.errorf("OfficeAccountOAuthHandler err: %v", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	query := r.URL.Query()
+	code, state := query.Get("code"), query.Get("state")
+	if code == "" && state == "" {
+		oaoh.redirect(w, r)
+		return
+	}
+	// The state must be the one we sent, so the code was requested by
+	// the browser of this user.
+	cookie, err := r.Cookie(oauthStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		oaoh.onError(w, r, errors.New("OfficeAccountOAuthHandler: state mismatch"))
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: oauthStateCookie, Path: "/", MaxAge: -1})
+	if code == "" {
+		oaoh.onError(w, r, errors.New("OfficeAccountOAuthHandler: authorization refused"))
+		return
+	}
+	res, err := NewOfficeAccountOAuthAccessToken(oaoh.client).
+		SetAppID(oaoh.appid).
+		SetSecret(oaoh.secret).
+		SetCode(code).
+		Do(r.Context())
+	if err != nil {
+		oaoh.onError(w, r, errors.Wrap(err, "OfficeAccountOAuthHandler"))
+		return
+	}
+	token := &res.OfficeAccountOAuthToken
+	if err := oaoh.Store().Save(r.Context(), token); err != nil {
+		oaoh.client.errorf("OfficeAccountOAuthHandler save token err: %v", err)
+	}
+	oaoh.onSuccess(w, r, token)
+}
+
+// redirect sends the user to wechat to authorize.
+func (oaoh *OfficeAccountOAuthHandler) redirect(w http.ResponseWriter, r *http.Request) {
+	state := string(RandomStr(16))
+	redirectURI := oaoh.redirectURI
+	if redirectURI == "" {
+		redirectURI = requestURL(r)
+	}
+	u, err := NewOfficeAccountOAuthURL(oaoh.client).
+		SetAppID(oaoh.appid).
+		SetRedirectURI(redirectURI).
+		SetScope(oaoh.scope).
+		SetState(state).
+		URL()
+	if err != nil {
+		oaoh.onError(w, r, errors.Wrap(err, "OfficeAccountOAuthHandler"))
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   300,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, u, http.StatusFound)
+}
+
+// requestURL returns the absolute url of r without code and state.
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	query := r.URL.Query()
+	query.Del("code")
+	query.Del("state")
+	u := url.URL{
+		Scheme:   scheme,
+		Host:     r.Host,
+		Path:     r.URL.Path,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
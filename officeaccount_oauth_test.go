@@ -0,0 +1,89 @@
+package wechat
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOfficeAccountOAuthHandler(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + OfficeAccountOAuthAccessTokenEndpoint:
+			if r.URL.Query().Get("code") != "CODE" {
+				w.Write([]byte(`{"errcode":40029,"errmsg":"invalid code"}`))
+				return
+			}
+			w.Write([]byte(`{"access_token":"expired","expires_in":0,"refresh_token":"REFRESH","openid":"OPENID","scope":"snsapi_base"}`))
+		case "/" + OfficeAccountOAuthRefreshTokenEndpoint:
+			w.Write([]byte(`{"access_token":"fresh","expires_in":7200,"refresh_token":"REFRESH","openid":"OPENID","scope":"snsapi_base"}`))
+		}
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(OfficeAccountHost, ts.URL))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var got *OfficeAccountOAuthToken
+	handler := client.OfficeAccountOAuthHandler().
+		SetAppID("appid").
+		SetSecret("secret").
+		SetSuccessHandler(func(w http.ResponseWriter, r *http.Request, token *OfficeAccountOAuthToken) {
+			got = token
+		})
+
+	// redirect to wechat
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://example.com/login?from=menu", nil))
+	location, err := url.Parse(w.Header().Get("Location"))
+	if err != nil || w.Code != http.StatusFound || !strings.HasSuffix(location.Path, OfficeAccountOAuthAuthorizeEndpoint) {
+		t.Log(w.Code, location, err)
+		t.FailNow()
+	}
+	query := location.Query()
+	state := query.Get("state")
+	if query.Get("redirect_uri") != "http://example.com/login?from=menu" || state == "" || len(w.Result().Cookies()) != 1 {
+		t.Log(location)
+		t.FailNow()
+	}
+	cookie := w.Result().Cookies()[0]
+
+	tests := []struct {
+		name     string
+		code     string
+		state    string
+		wantCode int
+	}{
+		{name: "state mismatch", code: "CODE", state: "other", wantCode: http.StatusBadRequest},
+		{name: "refused", state: state, wantCode: http.StatusBadRequest},
+		{name: "invalid code", code: "OTHER", state: state, wantCode: http.StatusBadRequest},
+		{name: "ok", code: "CODE", state: state, wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			r := httptest.NewRequest(http.MethodGet, "http://example.com/login?from=menu&code="+tt.code+"&state="+tt.state, nil)
+			r.AddCookie(cookie)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != tt.wantCode || (tt.wantCode == http.StatusOK) != (got != nil) {
+				t.Log(w.Code, w.Body.String(), got)
+				t.FailNow()
+			}
+		})
+	}
+
+	// the saved token is expired and refreshed
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	token, err := handler.Store().Token(ctx, "OPENID")
+	if err != nil || token.AccessToken != "fresh" {
+		t.Log(token, err)
+		t.FailNow()
+	}
+}
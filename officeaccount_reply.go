@@ -0,0 +1,182 @@
+package wechat
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// message types of passive replies
+const (
+	ReplyTypeText                    = "text"
+	ReplyTypeImage                   = "image"
+	ReplyTypeVoice                   = "voice"
+	ReplyTypeVideo                   = "video"
+	ReplyTypeMusic                   = "music"
+	ReplyTypeNews                    = "news"
+	ReplyTypeTransferCustomerService = "transfer_customer_service"
+)
+
+// OfficeAccountReplyHeader 被动回复消息的公共字段
+type OfficeAccountReplyHeader struct {
+	XMLName      xml.Name `xml:"xml" json:"-"`
+	ToUserName   CDATA    `xml:"ToUserName" json:"ToUserName"`
+	FromUserName CDATA    `xml:"FromUserName" json:"FromUserName"`
+	CreateTime   int64    `xml:"CreateTime" json:"CreateTime"`
+	MsgType      CDATA    `xml:"MsgType" json:"MsgType"`
+}
+
+// newOfficeAccountReplyHeader returns the header of a reply to msg, sent
+// from the office account back to the user.
+func newOfficeAccountReplyHeader(msg OfficeAccountMessage, msgType string) OfficeAccountReplyHeader {
+	header := msg.MessageHeader()
+	return OfficeAccountReplyHeader{
+		ToUserName:   CDATA(header.FromUserName),
+		FromUserName: CDATA(header.ToUserName),
+		CreateTime:   time.Now().Unix(),
+		MsgType:      CDATA(msgType),
+	}
+}
+
+// OfficeAccountTextReply 回复文本消息
+type OfficeAccountTextReply struct {
+	OfficeAccountReplyHeader
+	Content CDATA `xml:"Content" json:"Content"`
+}
+
+// NewOfficeAccountTextReply return instance of OfficeAccountTextReply
+func NewOfficeAccountTextReply(msg OfficeAccountMessage, content string) *OfficeAccountTextReply {
+	return &OfficeAccountTextReply{
+		OfficeAccountReplyHeader: newOfficeAccountReplyHeader(msg, ReplyTypeText),
+		Content:                  CDATA(content),
+	}
+}
+
+// OfficeAccountReplyMedia OfficeAccountReplyMedia
+type OfficeAccountReplyMedia struct {
+	MediaID CDATA `xml:"MediaId" json:"MediaId"`
+}
+
+// OfficeAccountImageReply 回复图片消息
+type OfficeAccountImageReply struct {
+	OfficeAccountReplyHeader
+	Image OfficeAccountReplyMedia `xml:"Image" json:"Image"`
+}
+
+// NewOfficeAccountImageReply return instance of OfficeAccountImageReply
+func NewOfficeAccountImageReply(msg OfficeAccountMessage, mediaID string) *OfficeAccountImageReply {
+	return &OfficeAccountImageReply{
+		OfficeAccountReplyHeader: newOfficeAccountReplyHeader(msg, ReplyTypeImage),
+		Image:                    OfficeAccountReplyMedia{MediaID: CDATA(mediaID)},
+	}
+}
+
+// OfficeAccountVoiceReply 回复语音消息
+type OfficeAccountVoiceReply struct {
+	OfficeAccountReplyHeader
+	Voice OfficeAccountReplyMedia `xml:"Voice" json:"Voice"`
+}
+
+// NewOfficeAccountVoiceReply return instance of OfficeAccountVoiceReply
+func NewOfficeAccountVoiceReply(msg OfficeAccountMessage, mediaID string) *OfficeAccountVoiceReply {
+	return &OfficeAccountVoiceReply{
+		OfficeAccountReplyHeader: newOfficeAccountReplyHeader(msg, ReplyTypeVoice),
+		Voice:                    OfficeAccountReplyMedia{MediaID: CDATA(mediaID)},
+	}
+}
+
+// OfficeAccountReplyVideo OfficeAccountReplyVideo
+type OfficeAccountReplyVideo struct {
+	MediaID     CDATA `xml:"MediaId" json:"MediaId"`
+	Title       CDATA `xml:"Title,omitempty" json:"Title,omitempty"`
+	Description CDATA `xml:"Description,omitempty" json:"Description,omitempty"`
+}
+
+// OfficeAccountVideoReply 回复视频消息
+type OfficeAccountVideoReply struct {
+	OfficeAccountReplyHeader
+	Video OfficeAccountReplyVideo `xml:"Video" json:"Video"`
+}
+
+// NewOfficeAccountVideoReply return instance of OfficeAccountVideoReply,
+// title and description are optional.
+func NewOfficeAccountVideoReply(msg OfficeAccountMessage, mediaID, title, description string) *OfficeAccountVideoReply {
+	return &OfficeAccountVideoReply{
+		OfficeAccountReplyHeader: newOfficeAccountReplyHeader(msg, ReplyTypeVideo),
+		Video: OfficeAccountReplyVideo{
+			MediaID:     CDATA(mediaID),
+			Title:       CDATA(title),
+			Description: CDATA(description),
+		},
+	}
+}
+
+// OfficeAccountReplyMusic OfficeAccountReplyMusic
+type OfficeAccountReplyMusic struct {
+	Title        CDATA `xml:"Title,omitempty" json:"Title,omitempty"`
+	Description  CDATA `xml:"Description,omitempty" json:"Description,omitempty"`
+	MusicURL     CDATA `xml:"MusicUrl,omitempty" json:"MusicUrl,omitempty"`
+	HQMusicURL   CDATA `xml:"HQMusicUrl,omitempty" json:"HQMusicUrl,omitempty"` // 高质量音乐链接，WIFI 环境优先使用
+	ThumbMediaID CDATA `xml:"ThumbMediaId" json:"ThumbMediaId"`
+}
+
+// OfficeAccountMusicReply 回复音乐消息
+type OfficeAccountMusicReply struct {
+	OfficeAccountReplyHeader
+	Music OfficeAccountReplyMusic `xml:"Music" json:"Music"`
+}
+
+// NewOfficeAccountMusicReply return instance of OfficeAccountMusicReply
+func NewOfficeAccountMusicReply(msg OfficeAccountMessage, music OfficeAccountReplyMusic) *OfficeAccountMusicReply {
+	return &OfficeAccountMusicReply{
+		OfficeAccountReplyHeader: newOfficeAccountReplyHeader(msg, ReplyTypeMusic),
+		Music:                    music,
+	}
+}
+
+// OfficeAccountReplyArticle 图文消息的文章
+type OfficeAccountReplyArticle struct {
+	Title       CDATA `xml:"Title" json:"Title"`
+	Description CDATA `xml:"Description" json:"Description"`
+	PicURL      CDATA `xml:"PicUrl" json:"PicUrl"` // 大图 360*200，小图 200*200
+	URL         CDATA `xml:"Url" json:"Url"`
+}
+
+// OfficeAccountNewsReply 回复图文消息，目前只支持 1 篇文章
+type OfficeAccountNewsReply struct {
+	OfficeAccountReplyHeader
+	ArticleCount int                         `xml:"ArticleCount" json:"ArticleCount"`
+	Articles     []OfficeAccountReplyArticle `xml:"Articles>item" json:"Articles"`
+}
+
+// NewOfficeAccountNewsReply return instance of OfficeAccountNewsReply
+func NewOfficeAccountNewsReply(msg OfficeAccountMessage, articles ...OfficeAccountReplyArticle) *OfficeAccountNewsReply {
+	return &OfficeAccountNewsReply{
+		OfficeAccountReplyHeader: newOfficeAccountReplyHeader(msg, ReplyTypeNews),
+		ArticleCount:             len(articles),
+		Articles:                 articles,
+	}
+}
+
+// OfficeAccountReplyTransInfo OfficeAccountReplyTransInfo
+type OfficeAccountReplyTransInfo struct {
+	KfAccount CDATA `xml:"KfAccount" json:"KfAccount"`
+}
+
+// OfficeAccountTransferCustomerServiceReply 将消息转发到客服
+type OfficeAccountTransferCustomerServiceReply struct {
+	OfficeAccountReplyHeader
+	TransInfo *OfficeAccountReplyTransInfo `xml:"TransInfo,omitempty" json:"TransInfo,omitempty"`
+}
+
+// NewOfficeAccountTransferCustomerServiceReply return instance of
+// OfficeAccountTransferCustomerServiceReply, kfAccount is the customer
+// service account to transfer to, any online one if empty.
+func NewOfficeAccountTransferCustomerServiceReply(msg OfficeAccountMessage, kfAccount string) *OfficeAccountTransferCustomerServiceReply {
+	reply := &OfficeAccountTransferCustomerServiceReply{
+		OfficeAccountReplyHeader: newOfficeAccountReplyHeader(msg, ReplyTypeTransferCustomerService),
+	}
+	if kfAccount != "" {
+		reply.TransInfo = &OfficeAccountReplyTransInfo{KfAccount: CDATA(kfAccount)}
+	}
+	return reply
+}
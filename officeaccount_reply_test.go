@@ -0,0 +1,78 @@
+package wechat
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestOfficeAccountReply_MarshalXML(t *testing.T) {
+	msg := &OfficeAccountTextMessage{CallbackMessageHeader: CallbackMessageHeader{ToUserName: "gh_account", FromUserName: "openid"}}
+	header := `<xml><ToUserName><!\[CDATA\[openid\]\]></ToUserName><FromUserName><!\[CDATA\[gh_account\]\]></FromUserName><CreateTime>\d+</CreateTime>`
+	tests := []struct {
+		name  string
+		reply interface{}
+		want  string
+	}{
+		{
+			name:  "text",
+			reply: NewOfficeAccountTextReply(msg, "<hello>"),
+			want:  `<MsgType><!\[CDATA\[text\]\]></MsgType><Content><!\[CDATA\[<hello>\]\]></Content></xml>`,
+		},
+		{
+			name:  "image",
+			reply: NewOfficeAccountImageReply(msg, "MEDIA_ID"),
+			want:  `<MsgType><!\[CDATA\[image\]\]></MsgType><Image><MediaId><!\[CDATA\[MEDIA_ID\]\]></MediaId></Image></xml>`,
+		},
+		{
+			name:  "music",
+			reply: NewOfficeAccountMusicReply(msg, OfficeAccountReplyMusic{Title: "TITLE", ThumbMediaID: "THUMB"}),
+			want:  `<MsgType><!\[CDATA\[music\]\]></MsgType><Music><Title><!\[CDATA\[TITLE\]\]></Title><ThumbMediaId><!\[CDATA\[THUMB\]\]></ThumbMediaId></Music></xml>`,
+		},
+		{
+			name:  "news",
+			reply: NewOfficeAccountNewsReply(msg, OfficeAccountReplyArticle{Title: "T", Description: "D", PicURL: "P", URL: "U"}),
+			want:  `<MsgType><!\[CDATA\[news\]\]></MsgType><ArticleCount>1</ArticleCount><Articles><item><Title><!\[CDATA\[T\]\]></Title><Description><!\[CDATA\[D\]\]></Description><PicUrl><!\[CDATA\[P\]\]></PicUrl><Url><!\[CDATA\[U\]\]></Url></item></Articles></xml>`,
+		},
+		{
+			name:  "transfer customer service",
+			reply: NewOfficeAccountTransferCustomerServiceReply(msg, ""),
+			want:  `<MsgType><!\[CDATA\[transfer_customer_service\]\]></MsgType></xml>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := xml.Marshal(tt.reply)
+			if err != nil || !regexp.MustCompile("^"+header+tt.want+"$").Match(out) {
+				t.Log(string(out), err)
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestEncryptReply(t *testing.T) {
+	msg := &OfficeAccountTextMessage{CallbackMessageHeader: CallbackMessageHeader{ToUserName: "gh_account", FromUserName: "openid"}}
+	reply := NewOfficeAccountTextReply(msg, "hello")
+	sealed, err := EncryptReply(reply, testCallbackToken, testCallbackAESKey, testCallbackAppID)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	crypto := &callbackCrypto{token: testCallbackToken, aesKey: testCallbackAESKey, receiveID: testCallbackAppID}
+	if !crypto.verify(string(sealed.MsgSignature), strconv.FormatInt(sealed.TimeStamp, 10), string(sealed.Nonce), string(sealed.Encrypt)) {
+		t.Log(sealed)
+		t.FailNow()
+	}
+	raw, err := crypto.decrypt(string(sealed.Encrypt))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	got := new(OfficeAccountTextMessage)
+	if err := xml.Unmarshal(raw, got); err != nil || got.Content != "hello" || got.ToUserName != "openid" {
+		t.Log(string(raw), err)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,117 @@
+package wechat
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// OfficeAccountHandlerFunc handles a message pushed to an office account.
+// A non-nil reply is sent back as passive reply, see OfficeAccountTextReply
+// and the other reply types: RawReply, string and []byte as they are,
+// anything else marshaled to XML. If reply is nil, ReplySuccess is sent.
+type OfficeAccountHandlerFunc func(ctx context.Context, msg OfficeAccountMessage) (reply interface{}, err error)
+
+// OfficeAccountServer 公众号消息推送服务器，is an http.Handler that answers
+// the URL verification of wechat, verifies, decrypts and decodes messages
+// and events, routes them to the handlers and encrypts the replies.
+type OfficeAccountServer struct {
+	cs callbackServer
+}
+
+// NewOfficeAccountServer return instance of OfficeAccountServer
+func NewOfficeAccountServer(client *Client) *OfficeAccountServer {
+	oas := &OfficeAccountServer{
+		cs: newCallbackServer(client, "OfficeAccountServer", "appid", func(raw []byte) (callbackMessage, error) {
+			return DecodeOfficeAccountMessage(raw)
+		}),
+	}
+	return oas
+}
+
+// SetAppID SetAppID
+func (oas *OfficeAccountServer) SetAppID(appid string) *OfficeAccountServer {
+	oas.cs.crypto.receiveID = appid
+	return oas
+}
+
+// SetToken 服务器配置的 Token
+func (oas *OfficeAccountServer) SetToken(token string) *OfficeAccountServer {
+	oas.cs.crypto.token = token
+	return oas
+}
+
+// SetEncodingAESKey 服务器配置的 EncodingAESKey，兼容模式和安全模式需要
+func (oas *OfficeAccountServer) SetEncodingAESKey(aesKey string) *OfficeAccountServer {
+	oas.cs.crypto.aesKey = aesKey
+	return oas
+}
+
+// SetMode CallbackModePlaintext (default), CallbackModeCompatible or
+// CallbackModeSafe
+func (oas *OfficeAccountServer) SetMode(mode CallbackMode) *OfficeAccountServer {
+	oas.cs.mode = mode
+	return oas
+}
+
+// HandleMessage registers the handler of a message type, e.g. MsgTypeText.
+func (oas *OfficeAccountServer) HandleMessage(msgType string, handler OfficeAccountHandlerFunc) *OfficeAccountServer {
+	oas.cs.handlers[callbackRoute(msgType, "")] = oas.wrap(handler)
+	return oas
+}
+
+// HandleEvent registers the handler of an event, e.g. EventSubscribe.
+func (oas *OfficeAccountServer) HandleEvent(event string, handler OfficeAccountHandlerFunc) *OfficeAccountServer {
+	oas.cs.handlers[callbackRoute(MsgTypeEvent, event)] = oas.wrap(handler)
+	return oas
+}
+
+// HandleDefault registers the handler of messages without a handler.
+// Without it, they are answered with ReplySuccess.
+func (oas *OfficeAccountServer) HandleDefault(handler OfficeAccountHandlerFunc) *OfficeAccountServer {
+	oas.cs.fallback = oas.wrap(handler)
+	return oas
+}
+
+func (oas *OfficeAccountServer) wrap(handler OfficeAccountHandlerFunc) callbackHandler {
+	return func(ctx context.Context, msg callbackMessage) (interface{}, error) {
+		return handler(ctx, msg)
+	}
+}
+
+// SetDedupWindow sets how long messages are remembered to drop the
+// retries of wechat, DefaultCallbackDedupWindow by default, 0 disables it.
+// Retries are answered with the reply to the first delivery, a retry that
+// arrives while the handler still runs waits for its reply or is answered
+// with ReplySuccess. The client cache is used, share it and a Locker
+// between instances to deduplicate across them, see SetLocker.
+func (oas *OfficeAccountServer) SetDedupWindow(window time.Duration) *OfficeAccountServer {
+	oas.cs.setDedupWindow(window)
+	return oas
+}
+
+// SetAsync answers ReplySuccess right away and handles the messages with
+// workers, at most queueSize messages wait for them and more are answered
+// with 503, see ErrCallbackQueueFull. Passive replies of the handlers are
+// dropped, and the handlers get a context without deadline.
+func (oas *OfficeAccountServer) SetAsync(workers, queueSize int) *OfficeAccountServer {
+	oas.cs.setAsync(workers, queueSize)
+	return oas
+}
+
+// Close stops the workers of SetAsync once the queued messages are
+// handled, new messages are answered with 503 then.
+func (oas *OfficeAccountServer) Close() error {
+	oas.cs.close()
+	return nil
+}
+
+// Validate checks if the server is valid.
+func (oas *OfficeAccountServer) Validate() error {
+	return oas.cs.validate()
+}
+
+// ServeHTTP ServeHTTP
+func (oas *OfficeAccountServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	oas.cs.serveHTTP(w, r)
+}
@@ -0,0 +1,253 @@
+package wechat
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	testCallbackToken  = "token"
+	testCallbackAESKey = "abcdefghijklmnopqrstuvwxyz0123456789ABCDEFG"
+	testCallbackAppID  = "wx5823bf96d3bd56c7"
+)
+
+// signedCallbackURL returns the url of a callback request signed like wechat
+// does, encrypt is the Encrypt field of safe mode.
+func signedCallbackURL(encrypt string) string {
+	q := url.Values{}
+	q.Set("timestamp", "1409304348")
+	q.Set("nonce", "xxxxxx")
+	q.Set("signature", Signature(testCallbackToken, "1409304348", "xxxxxx"))
+	if encrypt != "" {
+		q.Set("encrypt_type", "aes")
+		q.Set("msg_signature", Signature(testCallbackToken, "1409304348", "xxxxxx", encrypt))
+	}
+	return "http://example.com/callback?" + q.Encode()
+}
+
+func TestOfficeAccountServer(t *testing.T) {
+	client, err := NewClient()
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	server := client.OfficeAccountServer().
+		SetAppID(testCallbackAppID).
+		SetToken(testCallbackToken).
+		SetEncodingAESKey(testCallbackAESKey).
+		SetMode(CallbackModeCompatible).
+		HandleMessage(MsgTypeText, func(ctx context.Context, msg OfficeAccountMessage) (interface{}, error) {
+			return "echo:" + msg.(*OfficeAccountTextMessage).Content, nil
+		}).
+		HandleEvent(EventSubscribe, func(ctx context.Context, msg OfficeAccountMessage) (interface{}, error) {
+			return "welcome:" + msg.(*OfficeAccountSubscribeEvent).EventKey, nil
+		})
+
+	text := `<xml><ToUserName><![CDATA[toUser]]></ToUserName><FromUserName><![CDATA[fromUser]]></FromUserName><CreateTime>1348831860</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[hello]]></Content><MsgId>1234567890123456</MsgId></xml>`
+	subscribe := `<xml><ToUserName><![CDATA[toUser]]></ToUserName><FromUserName><![CDATA[fromUser]]></FromUserName><CreateTime>123456789</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[subscribe]]></Event><EventKey><![CDATA[qrscene_123]]></EventKey></xml>`
+	encrypted, err := EncryptMsg(RandomStr(16), []byte(subscribe), testCallbackAppID, testCallbackAESKey)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	envelope := `<xml><ToUserName><![CDATA[toUser]]></ToUserName><Encrypt><![CDATA[` + string(encrypted) + `]]></Encrypt></xml>`
+
+	tests := []struct {
+		name      string
+		method    string
+		url       string
+		body      string
+		wantCode  int
+		wantReply string
+		encrypted bool
+	}{
+		{name: "echostr", method: http.MethodGet, url: signedCallbackURL("") + "&echostr=ECHO", wantCode: http.StatusOK, wantReply: "ECHO"},
+		{name: "bad signature", method: http.MethodPost, url: "http://example.com/callback?signature=x", body: text, wantCode: http.StatusForbidden},
+		{name: "plaintext", method: http.MethodPost, url: signedCallbackURL(""), body: text, wantCode: http.StatusOK, wantReply: "echo:hello"},
+		{name: "encrypted", method: http.MethodPost, url: signedCallbackURL(string(encrypted)), body: envelope, wantCode: http.StatusOK, wantReply: "welcome:qrscene_123", encrypted: true},
+		{name: "no handler", method: http.MethodPost, url: signedCallbackURL(""), body: strings.NewReplacer("text", "link", "1234567890123456", "1234567890123457").Replace(text), wantCode: http.StatusOK, wantReply: "success"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Log(w.Code, w.Body.String())
+				t.FailNow()
+			}
+			reply := w.Body.Bytes()
+			if !tt.encrypted {
+				if tt.wantReply != "" && string(reply) != tt.wantReply {
+					t.Log(string(reply))
+					t.FailNow()
+				}
+				return
+			}
+			// the reply of an encrypted message is encrypted
+			sealed := new(EncryptedReply)
+			if err := xml.Unmarshal(reply, sealed); err != nil {
+				t.Log(string(reply), err)
+				t.FailNow()
+			}
+			crypto := &callbackCrypto{token: testCallbackToken, aesKey: testCallbackAESKey, receiveID: testCallbackAppID}
+			if !crypto.verify(string(sealed.MsgSignature), strconv.FormatInt(sealed.TimeStamp, 10), string(sealed.Nonce), string(sealed.Encrypt)) {
+				t.Log(string(reply))
+				t.FailNow()
+			}
+			raw, err := crypto.decrypt(string(sealed.Encrypt))
+			if err != nil || string(raw) != tt.wantReply {
+				t.Log(string(raw), err)
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestOfficeAccountServerRetries(t *testing.T) {
+	client, err := NewClient()
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	text := `<xml><ToUserName><![CDATA[toUser]]></ToUserName><FromUserName><![CDATA[fromUser]]></FromUserName><CreateTime>1348831860</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[hello]]></Content><MsgId>1234567890123456</MsgId></xml>`
+	post := func(server *OfficeAccountServer, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, signedCallbackURL(""), strings.NewReader(body)))
+		return w
+	}
+
+	// retries are answered with the first reply
+	calls := 0
+	server := client.OfficeAccountServer().
+		SetToken(testCallbackToken).
+		HandleMessage(MsgTypeText, func(ctx context.Context, msg OfficeAccountMessage) (interface{}, error) {
+			calls++
+			return "echo:" + strconv.Itoa(calls), nil
+		})
+	for i := 0; i < 3; i++ {
+		if w := post(server, text); w.Code != http.StatusOK || w.Body.String() != "echo:1" || calls != 1 {
+			t.Log(w.Code, w.Body.String(), calls)
+			t.FailNow()
+		}
+	}
+	if w := post(server, strings.Replace(text, "1234567890123456", "1234567890123457", 1)); w.Body.String() != "echo:2" {
+		t.Log(w.Body.String())
+		t.FailNow()
+	}
+
+	// a retry while the first delivery is handled waits for its reply
+	started, release := make(chan struct{}), make(chan struct{})
+	var slowCalls int32
+	server = client.OfficeAccountServer().
+		SetToken(testCallbackToken).
+		HandleMessage(MsgTypeText, func(ctx context.Context, msg OfficeAccountMessage) (interface{}, error) {
+			if atomic.AddInt32(&slowCalls, 1) == 1 {
+				close(started)
+				<-release
+			}
+			return "echo", nil
+		})
+	slow := strings.Replace(text, "1234567890123456", "1234567890123458", 1)
+	replies := make(chan *httptest.ResponseRecorder, 2)
+	go func() { replies <- post(server, slow) }()
+	<-started
+	go func() { replies <- post(server, slow) }()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	for i := 0; i < 2; i++ {
+		if w := <-replies; w.Body.String() != "echo" {
+			t.Log(i, w.Body.String())
+			t.FailNow()
+		}
+	}
+	if n := atomic.LoadInt32(&slowCalls); n != 1 {
+		t.Log("the handler should run once", n)
+		t.FailNow()
+	}
+
+	// concurrent deliveries to asynchronous servers sharing the cache are
+	// handled once
+	cache, err := NewMemCache()
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	locker := NewMemLocker()
+	var asyncCalls int32
+	servers := make([]*OfficeAccountServer, 2)
+	for i := range servers {
+		replica, err := NewClient(SetCacheBackend(cache), SetLocker(locker))
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+		servers[i] = replica.OfficeAccountServer().
+			SetToken(testCallbackToken).
+			SetAsync(1, 10).
+			HandleMessage(MsgTypeText, func(ctx context.Context, msg OfficeAccountMessage) (interface{}, error) {
+				atomic.AddInt32(&asyncCalls, 1)
+				return nil, nil
+			})
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(server *OfficeAccountServer) {
+			defer wg.Done()
+			if w := post(server, text); w.Code != http.StatusOK || w.Body.String() != string(ReplySuccess) {
+				t.Log(w.Code, w.Body.String())
+				t.Fail()
+			}
+		}(servers[i%2])
+	}
+	wg.Wait()
+	for _, server := range servers {
+		server.Close()
+	}
+	if n := atomic.LoadInt32(&asyncCalls); n != 1 {
+		t.Log("the message should be handled once", n)
+		t.FailNow()
+	}
+
+	// asynchronous handling
+	release = make(chan struct{})
+	handled := make(chan string, 3)
+	server = client.OfficeAccountServer().
+		SetToken(testCallbackToken).
+		SetDedupWindow(0).
+		SetAsync(1, 1).
+		HandleMessage(MsgTypeText, func(ctx context.Context, msg OfficeAccountMessage) (interface{}, error) {
+			<-release
+			handled <- msg.(*OfficeAccountTextMessage).Content
+			return "echo", nil
+		})
+	wantCodes := []int{http.StatusOK, http.StatusOK, http.StatusServiceUnavailable}
+	for i, want := range wantCodes {
+		// the first message is taken by the worker, the second waits in the queue
+		w := post(server, text)
+		if w.Code != want || (want == http.StatusOK && w.Body.String() != string(ReplySuccess)) {
+			t.Log(i, w.Code, w.Body.String())
+			t.FailNow()
+		}
+		if i == 0 {
+			for len(server.cs.pool.queue) != 0 {
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}
+	close(release)
+	server.Close()
+	if len(handled) != 2 {
+		t.Log(len(handled))
+		t.FailNow()
+	}
+}
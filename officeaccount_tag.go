@@ -0,0 +1,697 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Endpoint https://developers.weixin.qq.com/doc/offiaccount/User_Management/User_Tag_Management.html
+const (
+	OfficeAccountTagCreateEndpoint         = "cgi-bin/tags/create"
+	OfficeAccountTagGetEndpoint            = "cgi-bin/tags/get"
+	OfficeAccountTagUpdateEndpoint         = "cgi-bin/tags/update"
+	OfficeAccountTagDeleteEndpoint         = "cgi-bin/tags/delete"
+	OfficeAccountTagBatchTaggingEndpoint   = "cgi-bin/tags/members/batchtagging"
+	OfficeAccountTagBatchUntaggingEndpoint = "cgi-bin/tags/members/batchuntagging"
+	OfficeAccountTagUserGetEndpoint        = "cgi-bin/user/tag/get"
+	OfficeAccountTagIDListEndpoint         = "cgi-bin/tags/getidlist"
+)
+
+// limits of tags
+const (
+	officeAccountTagMaxName    = 30
+	officeAccountTagMaxOpenIDs = 50
+)
+
+// OfficeAccountTag 标签，Count 为标签下粉丝数
+type OfficeAccountTag struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int64  `json:"count,omitempty"`
+}
+
+func validateTagName(name string) error {
+	if name == "" {
+		return fmt.Errorf("missing required fields: %v", []string{"name"})
+	}
+	if utf8.RuneCountInString(name) > officeAccountTagMaxName {
+		return fmt.Errorf("name is longer than %d characters", officeAccountTagMaxName)
+	}
+	return nil
+}
+
+// -- create --
+
+// OfficeAccountTagCreate 创建标签，一个公众号最多可以创建 100 个标签
+type OfficeAccountTagCreate struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	name        string
+}
+
+// NewOfficeAccountTagCreate return instance of OfficeAccountTagCreate
+func NewOfficeAccountTagCreate(client *Client) *OfficeAccountTagCreate {
+	oatc := &OfficeAccountTagCreate{
+		client: client,
+	}
+	return oatc
+}
+
+// SetAccessToken SetAccessToken
+func (oatc *OfficeAccountTagCreate) SetAccessToken(accessToken string) *OfficeAccountTagCreate {
+	oatc.accessToken = accessToken
+	return oatc
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oatc *OfficeAccountTagCreate) SetAccessTokenSource(iat IAccessToken) *OfficeAccountTagCreate {
+	oatc.iat = iat
+	return oatc
+}
+
+// SetName 标签名，30 个字符以内
+func (oatc *OfficeAccountTagCreate) SetName(name string) *OfficeAccountTagCreate {
+	oatc.name = name
+	return oatc
+}
+
+// Validate checks if the operation is valid.
+func (oatc *OfficeAccountTagCreate) Validate() error {
+	if oatc.accessToken == "" && oatc.iat == nil {
+		return fmt.Errorf("missing required fields: %v", []string{"access_token"})
+	}
+	return validateTagName(oatc.name)
+}
+
+// Do Do
+func (oatc *OfficeAccountTagCreate) Do(ctx context.Context) (*OfficeAccountTagCreateResponse, error) {
+	// Check pre-conditions
+	if err := oatc.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagCreate.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]interface{}{
+		"tag": map[string]string{"name": oatc.name},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagCreate.Do")
+	}
+	res, err := oatc.client.performTokenRequest(ctx, oatc.accessToken, oatc.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountTagCreateEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagCreate.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountTagCreateResponse)
+	if err := oatc.client.decodeResponse(OfficeAccountTagCreateEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountTagCreate.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountTagCreateResponse OfficeAccountTagCreateResponse
+type OfficeAccountTagCreateResponse struct {
+	CommonError
+	Tag OfficeAccountTag `json:"tag"`
+}
+
+// -- get --
+
+// OfficeAccountTagGet 获取公众号已创建的标签
+type OfficeAccountTagGet struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+}
+
+// NewOfficeAccountTagGet return instance of OfficeAccountTagGet
+func NewOfficeAccountTagGet(client *Client) *OfficeAccountTagGet {
+	oatg := &OfficeAccountTagGet{
+		client: client,
+	}
+	return oatg
+}
+
+// SetAccessToken SetAccessToken
+func (oatg *OfficeAccountTagGet) SetAccessToken(accessToken string) *OfficeAccountTagGet {
+	oatg.accessToken = accessToken
+	return oatg
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oatg *OfficeAccountTagGet) SetAccessTokenSource(iat IAccessToken) *OfficeAccountTagGet {
+	oatg.iat = iat
+	return oatg
+}
+
+// Validate checks if the operation is valid.
+func (oatg *OfficeAccountTagGet) Validate() error {
+	if oatg.accessToken == "" && oatg.iat == nil {
+		return fmt.Errorf("missing required fields: %v", []string{"access_token"})
+	}
+	return nil
+}
+
+// Do Do
+func (oatg *OfficeAccountTagGet) Do(ctx context.Context) (*OfficeAccountTagGetResponse, error) {
+	// Check pre-conditions
+	if err := oatg.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagGet.Do")
+	}
+	res, err := oatg.client.performTokenRequest(ctx, oatg.accessToken, oatg.iat, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   url.Values{},
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountTagGetEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagGet.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountTagGetResponse)
+	if err := oatg.client.decodeResponse(OfficeAccountTagGetEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountTagGet.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountTagGetResponse OfficeAccountTagGetResponse
+type OfficeAccountTagGetResponse struct {
+	CommonError
+	Tags []OfficeAccountTag `json:"tags"`
+}
+
+// -- update --
+
+// OfficeAccountTagUpdate 编辑标签
+type OfficeAccountTagUpdate struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	id          int64
+	name        string
+}
+
+// NewOfficeAccountTagUpdate return instance of OfficeAccountTagUpdate
+func NewOfficeAccountTagUpdate(client *Client) *OfficeAccountTagUpdate {
+	oatu := &OfficeAccountTagUpdate{
+		client: client,
+	}
+	return oatu
+}
+
+// SetAccessToken SetAccessToken
+func (oatu *OfficeAccountTagUpdate) SetAccessToken(accessToken string) *OfficeAccountTagUpdate {
+	oatu.accessToken = accessToken
+	return oatu
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oatu *OfficeAccountTagUpdate) SetAccessTokenSource(iat IAccessToken) *OfficeAccountTagUpdate {
+	oatu.iat = iat
+	return oatu
+}
+
+// SetID SetID
+func (oatu *OfficeAccountTagUpdate) SetID(id int64) *OfficeAccountTagUpdate {
+	oatu.id = id
+	return oatu
+}
+
+// SetName 标签名，30 个字符以内
+func (oatu *OfficeAccountTagUpdate) SetName(name string) *OfficeAccountTagUpdate {
+	oatu.name = name
+	return oatu
+}
+
+// Validate checks if the operation is valid.
+func (oatu *OfficeAccountTagUpdate) Validate() error {
+	var invalid []string
+	if oatu.accessToken == "" && oatu.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if oatu.id == 0 {
+		invalid = append(invalid, "id")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return validateTagName(oatu.name)
+}
+
+// Do Do
+func (oatu *OfficeAccountTagUpdate) Do(ctx context.Context) (*OfficeAccountTagUpdateResponse, error) {
+	// Check pre-conditions
+	if err := oatu.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagUpdate.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]interface{}{
+		"tag": OfficeAccountTag{ID: oatu.id, Name: oatu.name},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagUpdate.Do")
+	}
+	res, err := oatu.client.performTokenRequest(ctx, oatu.accessToken, oatu.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountTagUpdateEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagUpdate.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountTagUpdateResponse)
+	if err := oatu.client.decodeResponse(OfficeAccountTagUpdateEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountTagUpdate.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountTagUpdateResponse OfficeAccountTagUpdateResponse
+type OfficeAccountTagUpdateResponse struct {
+	CommonError
+}
+
+// -- delete --
+
+// OfficeAccountTagDelete 删除标签，粉丝数超过 10 万的标签不能直接删除，需要先
+// 取消标签
+type OfficeAccountTagDelete struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	id          int64
+}
+
+// NewOfficeAccountTagDelete return instance of OfficeAccountTagDelete
+func NewOfficeAccountTagDelete(client *Client) *OfficeAccountTagDelete {
+	oatd := &OfficeAccountTagDelete{
+		client: client,
+	}
+	return oatd
+}
+
+// SetAccessToken SetAccessToken
+func (oatd *OfficeAccountTagDelete) SetAccessToken(accessToken string) *OfficeAccountTagDelete {
+	oatd.accessToken = accessToken
+	return oatd
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oatd *OfficeAccountTagDelete) SetAccessTokenSource(iat IAccessToken) *OfficeAccountTagDelete {
+	oatd.iat = iat
+	return oatd
+}
+
+// SetID SetID
+func (oatd *OfficeAccountTagDelete) SetID(id int64) *OfficeAccountTagDelete {
+	oatd.id = id
+	return oatd
+}
+
+// Validate checks if the operation is valid.
+func (oatd *OfficeAccountTagDelete) Validate() error {
+	var invalid []string
+	if oatd.accessToken == "" && oatd.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if oatd.id == 0 {
+		invalid = append(invalid, "id")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (oatd *OfficeAccountTagDelete) Do(ctx context.Context) (*OfficeAccountTagDeleteResponse, error) {
+	// Check pre-conditions
+	if err := oatd.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagDelete.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]interface{}{
+		"tag": map[string]int64{"id": oatd.id},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagDelete.Do")
+	}
+	res, err := oatd.client.performTokenRequest(ctx, oatd.accessToken, oatd.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountTagDeleteEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagDelete.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountTagDeleteResponse)
+	if err := oatd.client.decodeResponse(OfficeAccountTagDeleteEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountTagDelete.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountTagDeleteResponse OfficeAccountTagDeleteResponse
+type OfficeAccountTagDeleteResponse struct {
+	CommonError
+}
+
+// -- batch tagging --
+
+// OfficeAccountTagBatch 批量为用户打标签（batchtagging）或取消标签
+// （batchuntagging）。Wechat takes 50 openids per request, more are sent in
+// several requests. A failed request doesn't stop the next ones, the
+// response tells which openids failed.
+type OfficeAccountTagBatch struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	endpoint    string
+	tagID       int64
+	openids     []string
+}
+
+// NewOfficeAccountTagBatch return instance of OfficeAccountTagBatch,
+// endpoint is OfficeAccountTagBatchTaggingEndpoint or
+// OfficeAccountTagBatchUntaggingEndpoint.
+func NewOfficeAccountTagBatch(client *Client, endpoint string) *OfficeAccountTagBatch {
+	oatb := &OfficeAccountTagBatch{
+		client:   client,
+		endpoint: endpoint,
+	}
+	return oatb
+}
+
+// SetAccessToken SetAccessToken
+func (oatb *OfficeAccountTagBatch) SetAccessToken(accessToken string) *OfficeAccountTagBatch {
+	oatb.accessToken = accessToken
+	return oatb
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oatb *OfficeAccountTagBatch) SetAccessTokenSource(iat IAccessToken) *OfficeAccountTagBatch {
+	oatb.iat = iat
+	return oatb
+}
+
+// SetTagID SetTagID
+func (oatb *OfficeAccountTagBatch) SetTagID(tagID int64) *OfficeAccountTagBatch {
+	oatb.tagID = tagID
+	return oatb
+}
+
+// SetOpenIDs SetOpenIDs
+func (oatb *OfficeAccountTagBatch) SetOpenIDs(openids ...string) *OfficeAccountTagBatch {
+	oatb.openids = openids
+	return oatb
+}
+
+// Validate checks if the operation is valid.
+func (oatb *OfficeAccountTagBatch) Validate() error {
+	var invalid []string
+	if oatb.accessToken == "" && oatb.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if oatb.tagID == 0 {
+		invalid = append(invalid, "tagid")
+	}
+	if len(oatb.openids) == 0 {
+		invalid = append(invalid, "openid_list")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	if oatb.endpoint != OfficeAccountTagBatchTaggingEndpoint && oatb.endpoint != OfficeAccountTagBatchUntaggingEndpoint {
+		return fmt.Errorf("not allowed endpoint %q", oatb.endpoint)
+	}
+	return nil
+}
+
+// Do requests the openids by 50. If a request failed, it returns the error
+// of the first failure, and the response lists all of them.
+func (oatb *OfficeAccountTagBatch) Do(ctx context.Context) (*OfficeAccountTagBatchResponse, error) {
+	// Check pre-conditions
+	if err := oatb.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagBatch.Do")
+	}
+	ret := new(OfficeAccountTagBatchResponse)
+	for _, chunk := range chunkStrings(oatb.openids, officeAccountTagMaxOpenIDs) {
+		if err := oatb.do(ctx, chunk); err != nil {
+			ret.Failures = append(ret.Failures, OfficeAccountTagBatchFailure{OpenIDs: chunk, Err: err})
+			continue
+		}
+		ret.Succeeded = append(ret.Succeeded, chunk...)
+	}
+	if len(ret.Failures) > 0 {
+		return ret, errors.Wrapf(ret.Failures[0].Err, "OfficeAccountTagBatch.Do: %d of %d openids failed", len(oatb.openids)-len(ret.Succeeded), len(oatb.openids))
+	}
+	return ret, nil
+}
+
+func (oatb *OfficeAccountTagBatch) do(ctx context.Context, openids []string) error {
+	bodybyte, err := json.Marshal(map[string]interface{}{
+		"openid_list": openids,
+		"tagid":       oatb.tagID,
+	})
+	if err != nil {
+		return err
+	}
+	res, err := oatb.client.performTokenRequest(ctx, oatb.accessToken, oatb.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: oatb.endpoint,
+	})
+	if err != nil {
+		return err
+	}
+	return oatb.client.decodeChunkResponse(oatb.endpoint, res, new(CommonError))
+}
+
+// OfficeAccountTagBatchFailure is a request of OfficeAccountTagBatch that
+// failed.
+type OfficeAccountTagBatchFailure struct {
+	OpenIDs []string
+	Err     error
+}
+
+// OfficeAccountTagBatchResponse lists the openids that were (un)tagged, and
+// the failed requests.
+type OfficeAccountTagBatchResponse struct {
+	Succeeded []string
+	Failures  []OfficeAccountTagBatchFailure
+}
+
+// -- members --
+
+// OfficeAccountTagUserGet 获取标签下粉丝列表，每页最多 10000 个 openid
+type OfficeAccountTagUserGet struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	tagID       int64
+	nextOpenID  string
+}
+
+// NewOfficeAccountTagUserGet return instance of OfficeAccountTagUserGet
+func NewOfficeAccountTagUserGet(client *Client) *OfficeAccountTagUserGet {
+	oatug := &OfficeAccountTagUserGet{
+		client: client,
+	}
+	return oatug
+}
+
+// SetAccessToken SetAccessToken
+func (oatug *OfficeAccountTagUserGet) SetAccessToken(accessToken string) *OfficeAccountTagUserGet {
+	oatug.accessToken = accessToken
+	return oatug
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oatug *OfficeAccountTagUserGet) SetAccessTokenSource(iat IAccessToken) *OfficeAccountTagUserGet {
+	oatug.iat = iat
+	return oatug
+}
+
+// SetTagID SetTagID
+func (oatug *OfficeAccountTagUserGet) SetTagID(tagID int64) *OfficeAccountTagUserGet {
+	oatug.tagID = tagID
+	return oatug
+}
+
+// SetNextOpenID 第一个拉取的 openid，不填默认从头开始拉取
+func (oatug *OfficeAccountTagUserGet) SetNextOpenID(nextOpenID string) *OfficeAccountTagUserGet {
+	oatug.nextOpenID = nextOpenID
+	return oatug
+}
+
+// Validate checks if the operation is valid.
+func (oatug *OfficeAccountTagUserGet) Validate() error {
+	var invalid []string
+	if oatug.accessToken == "" && oatug.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if oatug.tagID == 0 {
+		invalid = append(invalid, "tagid")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (oatug *OfficeAccountTagUserGet) Do(ctx context.Context) (*OfficeAccountUserListResponse, error) {
+	return oatug.do(ctx, oatug.nextOpenID)
+}
+
+// Iterator returns an iterator over the pages, from SetNextOpenID on.
+func (oatug *OfficeAccountTagUserGet) Iterator() *OfficeAccountUserIterator {
+	return &OfficeAccountUserIterator{fetch: oatug.do, next: oatug.nextOpenID}
+}
+
+func (oatug *OfficeAccountTagUserGet) do(ctx context.Context, nextOpenID string) (*OfficeAccountUserListResponse, error) {
+	// Check pre-conditions
+	if err := oatug.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagUserGet.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]interface{}{
+		"tagid":       oatug.tagID,
+		"next_openid": nextOpenID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagUserGet.Do")
+	}
+	res, err := oatug.client.performTokenRequest(ctx, oatug.accessToken, oatug.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountTagUserGetEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagUserGet.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountUserListResponse)
+	if err := oatug.client.decodeResponse(OfficeAccountTagUserGetEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountTagUserGet.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountTagIDList 获取用户身上的标签列表
+type OfficeAccountTagIDList struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	openid      string
+}
+
+// NewOfficeAccountTagIDList return instance of OfficeAccountTagIDList
+func NewOfficeAccountTagIDList(client *Client) *OfficeAccountTagIDList {
+	oati := &OfficeAccountTagIDList{
+		client: client,
+	}
+	return oati
+}
+
+// SetAccessToken SetAccessToken
+func (oati *OfficeAccountTagIDList) SetAccessToken(accessToken string) *OfficeAccountTagIDList {
+	oati.accessToken = accessToken
+	return oati
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oati *OfficeAccountTagIDList) SetAccessTokenSource(iat IAccessToken) *OfficeAccountTagIDList {
+	oati.iat = iat
+	return oati
+}
+
+// SetOpenID SetOpenID
+func (oati *OfficeAccountTagIDList) SetOpenID(openid string) *OfficeAccountTagIDList {
+	oati.openid = openid
+	return oati
+}
+
+// Validate checks if the operation is valid.
+func (oati *OfficeAccountTagIDList) Validate() error {
+	var invalid []string
+	if oati.accessToken == "" && oati.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if oati.openid == "" {
+		invalid = append(invalid, "openid")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (oati *OfficeAccountTagIDList) Do(ctx context.Context) (*OfficeAccountTagIDListResponse, error) {
+	// Check pre-conditions
+	if err := oati.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagIDList.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]string{
+		"openid": oati.openid,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagIDList.Do")
+	}
+	res, err := oati.client.performTokenRequest(ctx, oati.accessToken, oati.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountTagIDListEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTagIDList.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountTagIDListResponse)
+	if err := oati.client.decodeResponse(OfficeAccountTagIDListEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountTagIDList.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountTagIDListResponse OfficeAccountTagIDListResponse
+type OfficeAccountTagIDListResponse struct {
+	CommonError
+	TagIDList []int64 `json:"tagid_list"`
+}
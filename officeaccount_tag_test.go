@@ -0,0 +1,69 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestOfficeAccountTagBatch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var req struct {
+			OpenIDList []string `json:"openid_list"`
+			TagID      int64    `json:"tagid"`
+		}
+		if r.URL.Path != "/"+OfficeAccountTagBatchTaggingEndpoint || json.Unmarshal(body, &req) != nil || req.TagID != 134 || len(req.OpenIDList) > 50 {
+			w.Write([]byte(`{"errcode":40003,"errmsg":"invalid openid"}`))
+			return
+		}
+		// the second chunk holds a user with too many tags
+		if req.OpenIDList[0] == "OPENID50" {
+			w.Write([]byte(`{"errcode":45059,"errmsg":"user too many tags"}`))
+			return
+		}
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer ts.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	openids := make([]string, 0, 120)
+	for i := 0; i < 120; i++ {
+		openids = append(openids, fmt.Sprintf("OPENID%d", i))
+	}
+	tests := []struct {
+		name         string
+		checkErrCode bool
+	}{
+		{name: "check errcode", checkErrCode: true},
+		// failed chunks are reported even if the errcode check is disabled
+		{name: "no errcode check", checkErrCode: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(SetHostURL(OfficeAccountHost, ts.URL), SetCheckErrCode(tt.checkErrCode))
+			if err != nil {
+				t.Log(err)
+				t.FailNow()
+			}
+			ret, err := client.OfficeAccountTagBatchTagging().SetAccessToken("token").SetTagID(134).SetOpenIDs(openids...).Do(ctx)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.ErrCode != ErrCodeUserTooManyTags {
+				t.Log(err)
+				t.FailNow()
+			}
+			if len(ret.Succeeded) != 70 || len(ret.Failures) != 1 || len(ret.Failures[0].OpenIDs) != 50 || ret.Failures[0].OpenIDs[0] != "OPENID50" {
+				t.Log(len(ret.Succeeded), ret.Failures)
+				t.FailNow()
+			}
+		})
+	}
+}
@@ -0,0 +1,449 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// Endpoint https://developers.weixin.qq.com/doc/offiaccount/Message_Management/Template_Message_Interface.html
+const (
+	OfficeAccountTemplateAddEndpoint    = "cgi-bin/template/api_add_template"
+	OfficeAccountTemplateGetAllEndpoint = "cgi-bin/template/get_all_private_template"
+	OfficeAccountTemplateDeleteEndpoint = "cgi-bin/template/del_private_template"
+	OfficeAccountIndustryGetEndpoint    = "cgi-bin/template/get_industry"
+	OfficeAccountIndustrySetEndpoint    = "cgi-bin/template/api_set_industry"
+)
+
+// -- templates --
+
+// OfficeAccountTemplateAdd 从模板库添加模板，获得模板 ID
+type OfficeAccountTemplateAdd struct {
+	client *Client
+
+	accessToken     string
+	iat             IAccessToken
+	templateIDShort string
+	keywordNames    []string
+}
+
+// NewOfficeAccountTemplateAdd return instance of OfficeAccountTemplateAdd
+func NewOfficeAccountTemplateAdd(client *Client) *OfficeAccountTemplateAdd {
+	oata := &OfficeAccountTemplateAdd{
+		client: client,
+	}
+	return oata
+}
+
+// SetAccessToken SetAccessToken
+func (oata *OfficeAccountTemplateAdd) SetAccessToken(accessToken string) *OfficeAccountTemplateAdd {
+	oata.accessToken = accessToken
+	return oata
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oata *OfficeAccountTemplateAdd) SetAccessTokenSource(iat IAccessToken) *OfficeAccountTemplateAdd {
+	oata.iat = iat
+	return oata
+}
+
+// SetTemplateIDShort 模板库中模板的编号，如 TM00015
+func (oata *OfficeAccountTemplateAdd) SetTemplateIDShort(templateIDShort string) *OfficeAccountTemplateAdd {
+	oata.templateIDShort = templateIDShort
+	return oata
+}
+
+// SetKeywordNames 选用的类目模板的关键词，按顺序传入
+func (oata *OfficeAccountTemplateAdd) SetKeywordNames(keywordNames ...string) *OfficeAccountTemplateAdd {
+	oata.keywordNames = keywordNames
+	return oata
+}
+
+// Validate checks if the operation is valid.
+func (oata *OfficeAccountTemplateAdd) Validate() error {
+	var invalid []string
+	if oata.accessToken == "" && oata.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if oata.templateIDShort == "" {
+		invalid = append(invalid, "template_id_short")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (oata *OfficeAccountTemplateAdd) Do(ctx context.Context) (*OfficeAccountTemplateAddResponse, error) {
+	// Check pre-conditions
+	if err := oata.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTemplateAdd.Do")
+	}
+	body := map[string]interface{}{
+		"template_id_short": oata.templateIDShort,
+	}
+	if len(oata.keywordNames) > 0 {
+		body["keyword_name_list"] = oata.keywordNames
+	}
+	bodybyte, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTemplateAdd.Do")
+	}
+	res, err := oata.client.performTokenRequest(ctx, oata.accessToken, oata.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountTemplateAddEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTemplateAdd.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountTemplateAddResponse)
+	if err := oata.client.decodeResponse(OfficeAccountTemplateAddEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountTemplateAdd.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountTemplateAddResponse OfficeAccountTemplateAddResponse
+type OfficeAccountTemplateAddResponse struct {
+	CommonError
+	TemplateID string `json:"template_id"`
+}
+
+// OfficeAccountTemplateGetAll 获取已添加至帐号下所有模板列表
+type OfficeAccountTemplateGetAll struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+}
+
+// NewOfficeAccountTemplateGetAll return instance of OfficeAccountTemplateGetAll
+func NewOfficeAccountTemplateGetAll(client *Client) *OfficeAccountTemplateGetAll {
+	oatg := &OfficeAccountTemplateGetAll{
+		client: client,
+	}
+	return oatg
+}
+
+// SetAccessToken SetAccessToken
+func (oatg *OfficeAccountTemplateGetAll) SetAccessToken(accessToken string) *OfficeAccountTemplateGetAll {
+	oatg.accessToken = accessToken
+	return oatg
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oatg *OfficeAccountTemplateGetAll) SetAccessTokenSource(iat IAccessToken) *OfficeAccountTemplateGetAll {
+	oatg.iat = iat
+	return oatg
+}
+
+// Validate checks if the operation is valid.
+func (oatg *OfficeAccountTemplateGetAll) Validate() error {
+	if oatg.accessToken == "" && oatg.iat == nil {
+		return fmt.Errorf("missing required fields: %v", []string{"access_token"})
+	}
+	return nil
+}
+
+// Do Do
+func (oatg *OfficeAccountTemplateGetAll) Do(ctx context.Context) (*OfficeAccountTemplateGetAllResponse, error) {
+	// Check pre-conditions
+	if err := oatg.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTemplateGetAll.Do")
+	}
+	res, err := oatg.client.performTokenRequest(ctx, oatg.accessToken, oatg.iat, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   url.Values{},
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountTemplateGetAllEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTemplateGetAll.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountTemplateGetAllResponse)
+	if err := oatg.client.decodeResponse(OfficeAccountTemplateGetAllEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountTemplateGetAll.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountTemplate 模板，Content 中 {{name.DATA}} 为 OATemplateData 的位置
+type OfficeAccountTemplate struct {
+	TemplateID      string `json:"template_id"`
+	Title           string `json:"title"`
+	PrimaryIndustry string `json:"primary_industry"`
+	DeputyIndustry  string `json:"deputy_industry"`
+	Content         string `json:"content"`
+	Example         string `json:"example"`
+}
+
+// OfficeAccountTemplateGetAllResponse OfficeAccountTemplateGetAllResponse
+type OfficeAccountTemplateGetAllResponse struct {
+	CommonError
+	TemplateList []OfficeAccountTemplate `json:"template_list"`
+}
+
+// OfficeAccountTemplateDelete 删除模板
+type OfficeAccountTemplateDelete struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	templateID  string
+}
+
+// NewOfficeAccountTemplateDelete return instance of OfficeAccountTemplateDelete
+func NewOfficeAccountTemplateDelete(client *Client) *OfficeAccountTemplateDelete {
+	oatd := &OfficeAccountTemplateDelete{
+		client: client,
+	}
+	return oatd
+}
+
+// SetAccessToken SetAccessToken
+func (oatd *OfficeAccountTemplateDelete) SetAccessToken(accessToken string) *OfficeAccountTemplateDelete {
+	oatd.accessToken = accessToken
+	return oatd
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oatd *OfficeAccountTemplateDelete) SetAccessTokenSource(iat IAccessToken) *OfficeAccountTemplateDelete {
+	oatd.iat = iat
+	return oatd
+}
+
+// SetTemplateID SetTemplateID
+func (oatd *OfficeAccountTemplateDelete) SetTemplateID(templateID string) *OfficeAccountTemplateDelete {
+	oatd.templateID = templateID
+	return oatd
+}
+
+// Validate checks if the operation is valid.
+func (oatd *OfficeAccountTemplateDelete) Validate() error {
+	var invalid []string
+	if oatd.accessToken == "" && oatd.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if oatd.templateID == "" {
+		invalid = append(invalid, "template_id")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (oatd *OfficeAccountTemplateDelete) Do(ctx context.Context) (*OfficeAccountTemplateDeleteResponse, error) {
+	// Check pre-conditions
+	if err := oatd.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTemplateDelete.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]string{
+		"template_id": oatd.templateID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTemplateDelete.Do")
+	}
+	res, err := oatd.client.performTokenRequest(ctx, oatd.accessToken, oatd.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountTemplateDeleteEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountTemplateDelete.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountTemplateDeleteResponse)
+	if err := oatd.client.decodeResponse(OfficeAccountTemplateDeleteEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountTemplateDelete.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountTemplateDeleteResponse OfficeAccountTemplateDeleteResponse
+type OfficeAccountTemplateDeleteResponse struct {
+	CommonError
+}
+
+// -- industry --
+
+// OfficeAccountIndustryGet 获取设置的行业信息
+type OfficeAccountIndustryGet struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+}
+
+// NewOfficeAccountIndustryGet return instance of OfficeAccountIndustryGet
+func NewOfficeAccountIndustryGet(client *Client) *OfficeAccountIndustryGet {
+	oaig := &OfficeAccountIndustryGet{
+		client: client,
+	}
+	return oaig
+}
+
+// SetAccessToken SetAccessToken
+func (oaig *OfficeAccountIndustryGet) SetAccessToken(accessToken string) *OfficeAccountIndustryGet {
+	oaig.accessToken = accessToken
+	return oaig
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oaig *OfficeAccountIndustryGet) SetAccessTokenSource(iat IAccessToken) *OfficeAccountIndustryGet {
+	oaig.iat = iat
+	return oaig
+}
+
+// Validate checks if the operation is valid.
+func (oaig *OfficeAccountIndustryGet) Validate() error {
+	if oaig.accessToken == "" && oaig.iat == nil {
+		return fmt.Errorf("missing required fields: %v", []string{"access_token"})
+	}
+	return nil
+}
+
+// Do Do
+func (oaig *OfficeAccountIndustryGet) Do(ctx context.Context) (*OfficeAccountIndustryGetResponse, error) {
+	// Check pre-conditions
+	if err := oaig.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountIndustryGet.Do")
+	}
+	res, err := oaig.client.performTokenRequest(ctx, oaig.accessToken, oaig.iat, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   url.Values{},
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountIndustryGetEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountIndustryGet.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountIndustryGetResponse)
+	if err := oaig.client.decodeResponse(OfficeAccountIndustryGetEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountIndustryGet.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountIndustry 行业，如 IT科技 互联网/电子商务
+type OfficeAccountIndustry struct {
+	FirstClass  string `json:"first_class"`
+	SecondClass string `json:"second_class"`
+}
+
+// OfficeAccountIndustryGetResponse OfficeAccountIndustryGetResponse
+type OfficeAccountIndustryGetResponse struct {
+	CommonError
+	PrimaryIndustry   OfficeAccountIndustry `json:"primary_industry"`
+	SecondaryIndustry OfficeAccountIndustry `json:"secondary_industry"`
+}
+
+// OfficeAccountIndustrySet 设置所属行业，每月可修改一次
+type OfficeAccountIndustrySet struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	industryID1 string
+	industryID2 string
+}
+
+// NewOfficeAccountIndustrySet return instance of OfficeAccountIndustrySet
+func NewOfficeAccountIndustrySet(client *Client) *OfficeAccountIndustrySet {
+	oais := &OfficeAccountIndustrySet{
+		client: client,
+	}
+	return oais
+}
+
+// SetAccessToken SetAccessToken
+func (oais *OfficeAccountIndustrySet) SetAccessToken(accessToken string) *OfficeAccountIndustrySet {
+	oais.accessToken = accessToken
+	return oais
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oais *OfficeAccountIndustrySet) SetAccessTokenSource(iat IAccessToken) *OfficeAccountIndustrySet {
+	oais.iat = iat
+	return oais
+}
+
+// SetIndustryIDs 公众号模板消息所属行业编号，主营行业和副营行业
+func (oais *OfficeAccountIndustrySet) SetIndustryIDs(industryID1, industryID2 string) *OfficeAccountIndustrySet {
+	oais.industryID1 = industryID1
+	oais.industryID2 = industryID2
+	return oais
+}
+
+// Validate checks if the operation is valid.
+func (oais *OfficeAccountIndustrySet) Validate() error {
+	var invalid []string
+	if oais.accessToken == "" && oais.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if oais.industryID1 == "" {
+		invalid = append(invalid, "industry_id1")
+	}
+	if oais.industryID2 == "" {
+		invalid = append(invalid, "industry_id2")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (oais *OfficeAccountIndustrySet) Do(ctx context.Context) (*OfficeAccountIndustrySetResponse, error) {
+	// Check pre-conditions
+	if err := oais.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountIndustrySet.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]string{
+		"industry_id1": oais.industryID1,
+		"industry_id2": oais.industryID2,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountIndustrySet.Do")
+	}
+	res, err := oais.client.performTokenRequest(ctx, oais.accessToken, oais.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountIndustrySetEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountIndustrySet.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountIndustrySetResponse)
+	if err := oais.client.decodeResponse(OfficeAccountIndustrySetEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountIndustrySet.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountIndustrySetResponse OfficeAccountIndustrySetResponse
+type OfficeAccountIndustrySetResponse struct {
+	CommonError
+}
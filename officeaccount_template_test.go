@@ -0,0 +1,73 @@
+package wechat
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOfficeAccountTemplate(t *testing.T) {
+	bodies := make(map[string]string)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies[r.URL.Path[1:]] = string(body)
+		switch r.URL.Path[1:] {
+		case OfficeAccountTemplateAddEndpoint:
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok","template_id":"TEMPLATE_ID"}`))
+		case OfficeAccountTemplateGetAllEndpoint:
+			w.Write([]byte(`{"template_list":[{"template_id":"TEMPLATE_ID","title":"领取奖金提醒","primary_industry":"IT科技","deputy_industry":"互联网|电子商务","content":"{{result.DATA}}\n\n领奖金额:{{withdrawMoney.DATA}}\n","example":"您已提交领奖申请\n\n领奖金额：xxxx元\n"}]}`))
+		case OfficeAccountIndustryGetEndpoint:
+			w.Write([]byte(`{"primary_industry":{"first_class":"运输与仓储","second_class":"快递"},"secondary_industry":{"first_class":"IT科技","second_class":"互联网|电子商务"}}`))
+		default:
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+		}
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(OfficeAccountHost, ts.URL))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	add, err := client.OfficeAccountTemplateAdd().SetAccessToken("token").SetTemplateIDShort("TM00015").Do(ctx)
+	if err != nil || add.TemplateID != "TEMPLATE_ID" || bodies[OfficeAccountTemplateAddEndpoint] != `{"template_id_short":"TM00015"}` {
+		t.Log(add, err, bodies[OfficeAccountTemplateAddEndpoint])
+		t.FailNow()
+	}
+	_, err = client.OfficeAccountTemplateAdd().SetAccessToken("token").SetTemplateIDShort("TM00015").SetKeywordNames("物品名称", "购买时间").Do(ctx)
+	if err != nil || bodies[OfficeAccountTemplateAddEndpoint] != `{"keyword_name_list":["物品名称","购买时间"],"template_id_short":"TM00015"}` {
+		t.Log(err, bodies[OfficeAccountTemplateAddEndpoint])
+		t.FailNow()
+	}
+
+	all, err := client.OfficeAccountTemplateGetAll().SetAccessToken("token").Do(ctx)
+	if err != nil || len(all.TemplateList) != 1 || all.TemplateList[0].TemplateID != "TEMPLATE_ID" || all.TemplateList[0].DeputyIndustry != "互联网|电子商务" {
+		t.Log(all, err)
+		t.FailNow()
+	}
+
+	if _, err := client.OfficeAccountTemplateDelete().SetAccessToken("token").SetTemplateID("TEMPLATE_ID").Do(ctx); err != nil || bodies[OfficeAccountTemplateDeleteEndpoint] != `{"template_id":"TEMPLATE_ID"}` {
+		t.Log(err, bodies[OfficeAccountTemplateDeleteEndpoint])
+		t.FailNow()
+	}
+
+	industry, err := client.OfficeAccountIndustryGet().SetAccessToken("token").Do(ctx)
+	if err != nil || industry.PrimaryIndustry.FirstClass != "运输与仓储" || industry.SecondaryIndustry.SecondClass != "互联网|电子商务" {
+		t.Log(industry, err)
+		t.FailNow()
+	}
+
+	if _, err := client.OfficeAccountIndustrySet().SetAccessToken("token").SetIndustryIDs("1", "4").Do(ctx); err != nil || bodies[OfficeAccountIndustrySetEndpoint] != `{"industry_id1":"1","industry_id2":"4"}` {
+		t.Log(err, bodies[OfficeAccountIndustrySetEndpoint])
+		t.FailNow()
+	}
+	if err := client.OfficeAccountIndustrySet().SetAccessToken("token").SetIndustryIDs("1", "").Validate(); err == nil {
+		t.Log("industry_id2 is required")
+		t.FailNow()
+	}
+}
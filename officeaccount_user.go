@@ -0,0 +1,721 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Endpoint https://developers.weixin.qq.com/doc/offiaccount/User_Management/Getting_a_User_List.html
+const (
+	OfficeAccountUserGetEndpoint         = "cgi-bin/user/get"
+	OfficeAccountUserInfoEndpoint        = "cgi-bin/user/info"
+	OfficeAccountUserBatchGetEndpoint    = "cgi-bin/user/info/batchget"
+	OfficeAccountUserRemarkEndpoint      = "cgi-bin/user/info/updateremark"
+	OfficeAccountBlacklistGetEndpoint    = "cgi-bin/tags/members/getblacklist"
+	OfficeAccountBlacklistAddEndpoint    = "cgi-bin/tags/members/batchblacklist"
+	OfficeAccountBlacklistRemoveEndpoint = "cgi-bin/tags/members/batchunblacklist"
+)
+
+// limits of user management
+const (
+	officeAccountUserBatchGetMaxOpenIDs = 100
+	officeAccountBlacklistMaxOpenIDs    = 20
+	officeAccountUserRemarkMaxLength    = 30
+)
+
+// OfficeAccountUser 用户基本信息，Subscribe 为 0 时用户未关注，其他字段为空。
+// SubscribeScene 为 ADD_SCENE_SEARCH、ADD_SCENE_QR_CODE 等
+type OfficeAccountUser struct {
+	Subscribe      int64   `json:"subscribe"`
+	OpenID         string  `json:"openid"`
+	Language       string  `json:"language"`
+	SubscribeTime  int64   `json:"subscribe_time"`
+	UnionID        string  `json:"unionid"`
+	Remark         string  `json:"remark"`
+	GroupID        int64   `json:"groupid"`
+	TagIDList      []int64 `json:"tagid_list"`
+	SubscribeScene string  `json:"subscribe_scene"`
+	QrScene        int64   `json:"qr_scene"`
+	QrSceneStr     string  `json:"qr_scene_str"`
+}
+
+// OfficeAccountUserListResponse is a page of openids, of followers or of
+// the blacklist. Use NextOpenID to get the next page, or an
+// OfficeAccountUserIterator.
+type OfficeAccountUserListResponse struct {
+	CommonError
+	Total int64 `json:"total"`
+	Count int64 `json:"count"`
+	Data  struct {
+		OpenIDs []string `json:"openid"`
+	} `json:"data"`
+	NextOpenID string `json:"next_openid"`
+}
+
+// OfficeAccountUserIterator follows the next_openid of the pages of
+// openids:
+//
+//	it := client.OfficeAccountUserGet().SetAccessTokenSource(iat).Iterator()
+//	for it.Next(ctx) {
+//		openids := it.OpenIDs()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type OfficeAccountUserIterator struct {
+	fetch func(ctx context.Context, next string) (*OfficeAccountUserListResponse, error)
+	next  string
+	page  *OfficeAccountUserListResponse
+	done  bool
+	err   error
+}
+
+// Next gets the next page, it returns false at the end or on error.
+func (it *OfficeAccountUserIterator) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	page, err := it.fetch(ctx, it.next)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page = page
+	if len(page.Data.OpenIDs) == 0 {
+		it.done = true
+		return false
+	}
+	// the last page may end with its next_openid, the next request is empty
+	if page.NextOpenID == "" || page.NextOpenID == it.next {
+		it.done = true
+	}
+	it.next = page.NextOpenID
+	return true
+}
+
+// OpenIDs returns the openids of the current page.
+func (it *OfficeAccountUserIterator) OpenIDs() []string {
+	if it.page == nil {
+		return nil
+	}
+	return it.page.Data.OpenIDs
+}
+
+// Total returns the total number of openids, known after the first Next.
+func (it *OfficeAccountUserIterator) Total() int64 {
+	if it.page == nil {
+		return 0
+	}
+	return it.page.Total
+}
+
+// Err returns the error that stopped Next.
+func (it *OfficeAccountUserIterator) Err() error {
+	return it.err
+}
+
+// -- followers --
+
+// OfficeAccountUserGet 获取关注者列表，每页最多 10000 个 openid
+type OfficeAccountUserGet struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	nextOpenID  string
+}
+
+// NewOfficeAccountUserGet return instance of OfficeAccountUserGet
+func NewOfficeAccountUserGet(client *Client) *OfficeAccountUserGet {
+	oaug := &OfficeAccountUserGet{
+		client: client,
+	}
+	return oaug
+}
+
+// SetAccessToken SetAccessToken
+func (oaug *OfficeAccountUserGet) SetAccessToken(accessToken string) *OfficeAccountUserGet {
+	oaug.accessToken = accessToken
+	return oaug
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oaug *OfficeAccountUserGet) SetAccessTokenSource(iat IAccessToken) *OfficeAccountUserGet {
+	oaug.iat = iat
+	return oaug
+}
+
+// SetNextOpenID 第一个拉取的 openid，不填默认从头开始拉取
+func (oaug *OfficeAccountUserGet) SetNextOpenID(nextOpenID string) *OfficeAccountUserGet {
+	oaug.nextOpenID = nextOpenID
+	return oaug
+}
+
+// Validate checks if the operation is valid.
+func (oaug *OfficeAccountUserGet) Validate() error {
+	if oaug.accessToken == "" && oaug.iat == nil {
+		return fmt.Errorf("missing required fields: %v", []string{"access_token"})
+	}
+	return nil
+}
+
+// Do Do
+func (oaug *OfficeAccountUserGet) Do(ctx context.Context) (*OfficeAccountUserListResponse, error) {
+	return oaug.do(ctx, oaug.nextOpenID)
+}
+
+// Iterator returns an iterator over the pages, from SetNextOpenID on.
+func (oaug *OfficeAccountUserGet) Iterator() *OfficeAccountUserIterator {
+	return &OfficeAccountUserIterator{fetch: oaug.do, next: oaug.nextOpenID}
+}
+
+func (oaug *OfficeAccountUserGet) do(ctx context.Context, nextOpenID string) (*OfficeAccountUserListResponse, error) {
+	// Check pre-conditions
+	if err := oaug.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountUserGet.Do")
+	}
+	params := url.Values{}
+	if nextOpenID != "" {
+		params.Set("next_openid", nextOpenID)
+	}
+	res, err := oaug.client.performTokenRequest(ctx, oaug.accessToken, oaug.iat, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   params,
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountUserGetEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountUserGet.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountUserListResponse)
+	if err := oaug.client.decodeResponse(OfficeAccountUserGetEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountUserGet.Do")
+	}
+	return ret, nil
+}
+
+// -- user info --
+
+// OfficeAccountUserInfo 获取用户基本信息
+type OfficeAccountUserInfo struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	openid      string
+	lang        string
+}
+
+// NewOfficeAccountUserInfo return instance of OfficeAccountUserInfo
+func NewOfficeAccountUserInfo(client *Client) *OfficeAccountUserInfo {
+	oaui := &OfficeAccountUserInfo{
+		client: client,
+		lang:   "zh_CN",
+	}
+	return oaui
+}
+
+// SetAccessToken SetAccessToken
+func (oaui *OfficeAccountUserInfo) SetAccessToken(accessToken string) *OfficeAccountUserInfo {
+	oaui.accessToken = accessToken
+	return oaui
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oaui *OfficeAccountUserInfo) SetAccessTokenSource(iat IAccessToken) *OfficeAccountUserInfo {
+	oaui.iat = iat
+	return oaui
+}
+
+// SetOpenID SetOpenID
+func (oaui *OfficeAccountUserInfo) SetOpenID(openid string) *OfficeAccountUserInfo {
+	oaui.openid = openid
+	return oaui
+}
+
+// SetLang zh_CN (default), zh_TW or en
+func (oaui *OfficeAccountUserInfo) SetLang(lang string) *OfficeAccountUserInfo {
+	oaui.lang = lang
+	return oaui
+}
+
+// Validate checks if the operation is valid.
+func (oaui *OfficeAccountUserInfo) Validate() error {
+	var invalid []string
+	if oaui.accessToken == "" && oaui.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if oaui.openid == "" {
+		invalid = append(invalid, "openid")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do Do
+func (oaui *OfficeAccountUserInfo) Do(ctx context.Context) (*OfficeAccountUserInfoResponse, error) {
+	// Check pre-conditions
+	if err := oaui.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountUserInfo.Do")
+	}
+	params := url.Values{}
+	params.Set("openid", oaui.openid)
+	params.Set("lang", oaui.lang)
+	res, err := oaui.client.performTokenRequest(ctx, oaui.accessToken, oaui.iat, PerformRequestOptions{
+		Method:   http.MethodGet,
+		Params:   params,
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountUserInfoEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountUserInfo.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountUserInfoResponse)
+	if err := oaui.client.decodeResponse(OfficeAccountUserInfoEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountUserInfo.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountUserInfoResponse OfficeAccountUserInfoResponse
+type OfficeAccountUserInfoResponse struct {
+	CommonError
+	OfficeAccountUser
+}
+
+// OfficeAccountUserBatchGet 批量获取用户基本信息。Wechat takes 100 openids
+// per request, more are sent in several requests.
+type OfficeAccountUserBatchGet struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	openids     []string
+	lang        string
+}
+
+// NewOfficeAccountUserBatchGet return instance of OfficeAccountUserBatchGet
+func NewOfficeAccountUserBatchGet(client *Client) *OfficeAccountUserBatchGet {
+	oaub := &OfficeAccountUserBatchGet{
+		client: client,
+		lang:   "zh_CN",
+	}
+	return oaub
+}
+
+// SetAccessToken SetAccessToken
+func (oaub *OfficeAccountUserBatchGet) SetAccessToken(accessToken string) *OfficeAccountUserBatchGet {
+	oaub.accessToken = accessToken
+	return oaub
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oaub *OfficeAccountUserBatchGet) SetAccessTokenSource(iat IAccessToken) *OfficeAccountUserBatchGet {
+	oaub.iat = iat
+	return oaub
+}
+
+// SetOpenIDs SetOpenIDs
+func (oaub *OfficeAccountUserBatchGet) SetOpenIDs(openids ...string) *OfficeAccountUserBatchGet {
+	oaub.openids = openids
+	return oaub
+}
+
+// SetLang zh_CN (default), zh_TW or en
+func (oaub *OfficeAccountUserBatchGet) SetLang(lang string) *OfficeAccountUserBatchGet {
+	oaub.lang = lang
+	return oaub
+}
+
+// Validate checks if the operation is valid.
+func (oaub *OfficeAccountUserBatchGet) Validate() error {
+	var invalid []string
+	if oaub.accessToken == "" && oaub.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if len(oaub.openids) == 0 {
+		invalid = append(invalid, "openid")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do requests the openids by 100, in order, and stops at the first failed
+// request, even if SetCheckErrCode is disabled. On error, the response
+// holds the users got so far, the openids of the failed request and its
+// errcode.
+func (oaub *OfficeAccountUserBatchGet) Do(ctx context.Context) (*OfficeAccountUserBatchGetResponse, error) {
+	// Check pre-conditions
+	if err := oaub.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountUserBatchGet.Do")
+	}
+	ret := new(OfficeAccountUserBatchGetResponse)
+	chunks := chunkStrings(oaub.openids, officeAccountUserBatchGetMaxOpenIDs)
+	for i, chunk := range chunks {
+		page, err := oaub.do(ctx, chunk)
+		if err != nil {
+			if page != nil {
+				ret.CommonError = page.CommonError
+			}
+			ret.FailedOpenIDs = chunk
+			return ret, errors.Wrapf(err, "OfficeAccountUserBatchGet.Do: request %d of %d failed", i+1, len(chunks))
+		}
+		ret.UserInfoList = append(ret.UserInfoList, page.UserInfoList...)
+	}
+	return ret, nil
+}
+
+func (oaub *OfficeAccountUserBatchGet) do(ctx context.Context, openids []string) (*OfficeAccountUserBatchGetResponse, error) {
+	type user struct {
+		OpenID string `json:"openid"`
+		Lang   string `json:"lang"`
+	}
+	users := make([]user, 0, len(openids))
+	for _, openid := range openids {
+		users = append(users, user{OpenID: openid, Lang: oaub.lang})
+	}
+	bodybyte, err := json.Marshal(map[string]interface{}{
+		"user_list": users,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, err := oaub.client.performTokenRequest(ctx, oaub.accessToken, oaub.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountUserBatchGetEndpoint,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ret := new(OfficeAccountUserBatchGetResponse)
+	return ret, oaub.client.decodeChunkResponse(OfficeAccountUserBatchGetEndpoint, res, ret)
+}
+
+// OfficeAccountUserBatchGetResponse OfficeAccountUserBatchGetResponse
+type OfficeAccountUserBatchGetResponse struct {
+	CommonError
+	UserInfoList  []OfficeAccountUser `json:"user_info_list"`
+	FailedOpenIDs []string            `json:"-"` // openids of the failed request
+}
+
+// chunkStrings splits s into chunks of at most size strings.
+func chunkStrings(s []string, size int) [][]string {
+	var chunks [][]string
+	for len(s) > size {
+		chunks = append(chunks, s[:size])
+		s = s[size:]
+	}
+	if len(s) > 0 {
+		chunks = append(chunks, s)
+	}
+	return chunks
+}
+
+// -- remark --
+
+// OfficeAccountUserRemark 设置用户备注名，备注名长度必须小于 30 个字符
+type OfficeAccountUserRemark struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	openid      string
+	remark      string
+}
+
+// NewOfficeAccountUserRemark return instance of OfficeAccountUserRemark
+func NewOfficeAccountUserRemark(client *Client) *OfficeAccountUserRemark {
+	oaur := &OfficeAccountUserRemark{
+		client: client,
+	}
+	return oaur
+}
+
+// SetAccessToken SetAccessToken
+func (oaur *OfficeAccountUserRemark) SetAccessToken(accessToken string) *OfficeAccountUserRemark {
+	oaur.accessToken = accessToken
+	return oaur
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oaur *OfficeAccountUserRemark) SetAccessTokenSource(iat IAccessToken) *OfficeAccountUserRemark {
+	oaur.iat = iat
+	return oaur
+}
+
+// SetOpenID SetOpenID
+func (oaur *OfficeAccountUserRemark) SetOpenID(openid string) *OfficeAccountUserRemark {
+	oaur.openid = openid
+	return oaur
+}
+
+// SetRemark SetRemark
+func (oaur *OfficeAccountUserRemark) SetRemark(remark string) *OfficeAccountUserRemark {
+	oaur.remark = remark
+	return oaur
+}
+
+// Validate checks if the operation is valid.
+func (oaur *OfficeAccountUserRemark) Validate() error {
+	var invalid []string
+	if oaur.accessToken == "" && oaur.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if oaur.openid == "" {
+		invalid = append(invalid, "openid")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	if utf8.RuneCountInString(oaur.remark) >= officeAccountUserRemarkMaxLength {
+		return fmt.Errorf("remark is not shorter than %d characters", officeAccountUserRemarkMaxLength)
+	}
+	return nil
+}
+
+// Do Do
+func (oaur *OfficeAccountUserRemark) Do(ctx context.Context) (*OfficeAccountUserRemarkResponse, error) {
+	// Check pre-conditions
+	if err := oaur.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountUserRemark.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]string{
+		"openid": oaur.openid,
+		"remark": oaur.remark,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountUserRemark.Do")
+	}
+	res, err := oaur.client.performTokenRequest(ctx, oaur.accessToken, oaur.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountUserRemarkEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountUserRemark.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountUserRemarkResponse)
+	if err := oaur.client.decodeResponse(OfficeAccountUserRemarkEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountUserRemark.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountUserRemarkResponse OfficeAccountUserRemarkResponse
+type OfficeAccountUserRemarkResponse struct {
+	CommonError
+}
+
+// -- blacklist --
+
+// OfficeAccountBlacklistGet 获取公众号的黑名单列表，每页最多 10000 个 openid
+type OfficeAccountBlacklistGet struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	beginOpenID string
+}
+
+// NewOfficeAccountBlacklistGet return instance of OfficeAccountBlacklistGet
+func NewOfficeAccountBlacklistGet(client *Client) *OfficeAccountBlacklistGet {
+	oabg := &OfficeAccountBlacklistGet{
+		client: client,
+	}
+	return oabg
+}
+
+// SetAccessToken SetAccessToken
+func (oabg *OfficeAccountBlacklistGet) SetAccessToken(accessToken string) *OfficeAccountBlacklistGet {
+	oabg.accessToken = accessToken
+	return oabg
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oabg *OfficeAccountBlacklistGet) SetAccessTokenSource(iat IAccessToken) *OfficeAccountBlacklistGet {
+	oabg.iat = iat
+	return oabg
+}
+
+// SetBeginOpenID 第一个拉取的 openid，不填默认从头开始拉取
+func (oabg *OfficeAccountBlacklistGet) SetBeginOpenID(beginOpenID string) *OfficeAccountBlacklistGet {
+	oabg.beginOpenID = beginOpenID
+	return oabg
+}
+
+// Validate checks if the operation is valid.
+func (oabg *OfficeAccountBlacklistGet) Validate() error {
+	if oabg.accessToken == "" && oabg.iat == nil {
+		return fmt.Errorf("missing required fields: %v", []string{"access_token"})
+	}
+	return nil
+}
+
+// Do Do
+func (oabg *OfficeAccountBlacklistGet) Do(ctx context.Context) (*OfficeAccountUserListResponse, error) {
+	return oabg.do(ctx, oabg.beginOpenID)
+}
+
+// Iterator returns an iterator over the pages, from SetBeginOpenID on.
+func (oabg *OfficeAccountBlacklistGet) Iterator() *OfficeAccountUserIterator {
+	return &OfficeAccountUserIterator{fetch: oabg.do, next: oabg.beginOpenID}
+}
+
+func (oabg *OfficeAccountBlacklistGet) do(ctx context.Context, beginOpenID string) (*OfficeAccountUserListResponse, error) {
+	// Check pre-conditions
+	if err := oabg.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountBlacklistGet.Do")
+	}
+	bodybyte, err := json.Marshal(map[string]string{
+		"begin_openid": beginOpenID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountBlacklistGet.Do")
+	}
+	res, err := oabg.client.performTokenRequest(ctx, oabg.accessToken, oabg.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: OfficeAccountBlacklistGetEndpoint,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountBlacklistGet.Do")
+	}
+	// Return operation response
+	ret := new(OfficeAccountUserListResponse)
+	if err := oabg.client.decodeResponse(OfficeAccountBlacklistGetEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "OfficeAccountBlacklistGet.Do")
+	}
+	return ret, nil
+}
+
+// OfficeAccountBlacklistUpdate 拉黑用户（batchblacklist）或取消拉黑用户
+// （batchunblacklist）。Wechat takes 20 openids per request, more are sent
+// in several requests.
+type OfficeAccountBlacklistUpdate struct {
+	client *Client
+
+	accessToken string
+	iat         IAccessToken
+	endpoint    string
+	openids     []string
+}
+
+// NewOfficeAccountBlacklistUpdate return instance of OfficeAccountBlacklistUpdate,
+// endpoint is OfficeAccountBlacklistAddEndpoint or
+// OfficeAccountBlacklistRemoveEndpoint.
+func NewOfficeAccountBlacklistUpdate(client *Client, endpoint string) *OfficeAccountBlacklistUpdate {
+	oabu := &OfficeAccountBlacklistUpdate{
+		client:   client,
+		endpoint: endpoint,
+	}
+	return oabu
+}
+
+// SetAccessToken SetAccessToken
+func (oabu *OfficeAccountBlacklistUpdate) SetAccessToken(accessToken string) *OfficeAccountBlacklistUpdate {
+	oabu.accessToken = accessToken
+	return oabu
+}
+
+// SetAccessTokenSource sets the source of access tokens, which are then
+// managed and refreshed by BasicAccessToken. It replaces SetAccessToken.
+func (oabu *OfficeAccountBlacklistUpdate) SetAccessTokenSource(iat IAccessToken) *OfficeAccountBlacklistUpdate {
+	oabu.iat = iat
+	return oabu
+}
+
+// SetOpenIDs SetOpenIDs
+func (oabu *OfficeAccountBlacklistUpdate) SetOpenIDs(openids ...string) *OfficeAccountBlacklistUpdate {
+	oabu.openids = openids
+	return oabu
+}
+
+// Validate checks if the operation is valid.
+func (oabu *OfficeAccountBlacklistUpdate) Validate() error {
+	var invalid []string
+	if oabu.accessToken == "" && oabu.iat == nil {
+		invalid = append(invalid, "access_token")
+	}
+	if len(oabu.openids) == 0 {
+		invalid = append(invalid, "openid_list")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	if oabu.endpoint != OfficeAccountBlacklistAddEndpoint && oabu.endpoint != OfficeAccountBlacklistRemoveEndpoint {
+		return fmt.Errorf("not allowed endpoint %q", oabu.endpoint)
+	}
+	return nil
+}
+
+// Do requests the openids by 20, in order, and stops at the first failed
+// request, even if SetCheckErrCode is disabled. The response lists the
+// openids that were updated and, on error, the openids of the failed
+// request with its errcode.
+func (oabu *OfficeAccountBlacklistUpdate) Do(ctx context.Context) (*OfficeAccountBlacklistUpdateResponse, error) {
+	// Check pre-conditions
+	if err := oabu.Validate(); err != nil {
+		return nil, errors.Wrap(err, "OfficeAccountBlacklistUpdate.Do")
+	}
+	ret := new(OfficeAccountBlacklistUpdateResponse)
+	chunks := chunkStrings(oabu.openids, officeAccountBlacklistMaxOpenIDs)
+	for i, chunk := range chunks {
+		if err := oabu.do(ctx, chunk, &ret.CommonError); err != nil {
+			ret.FailedOpenIDs = chunk
+			return ret, errors.Wrapf(err, "OfficeAccountBlacklistUpdate.Do: request %d of %d failed", i+1, len(chunks))
+		}
+		ret.Succeeded = append(ret.Succeeded, chunk...)
+	}
+	return ret, nil
+}
+
+func (oabu *OfficeAccountBlacklistUpdate) do(ctx context.Context, openids []string, ce *CommonError) error {
+	bodybyte, err := json.Marshal(map[string]interface{}{
+		"openid_list": openids,
+	})
+	if err != nil {
+		return err
+	}
+	res, err := oabu.client.performTokenRequest(ctx, oabu.accessToken, oabu.iat, PerformRequestOptions{
+		Method:   http.MethodPost,
+		Params:   url.Values{},
+		Body:     string(bodybyte),
+		BaseURI:  OfficeAccountHost,
+		Endpoint: oabu.endpoint,
+	})
+	if err != nil {
+		return err
+	}
+	return oabu.client.decodeChunkResponse(oabu.endpoint, res, ce)
+}
+
+// OfficeAccountBlacklistUpdateResponse OfficeAccountBlacklistUpdateResponse
+type OfficeAccountBlacklistUpdateResponse struct {
+	CommonError
+	Succeeded     []string // openids that were updated
+	FailedOpenIDs []string // openids of the failed request
+}
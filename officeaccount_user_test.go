@@ -0,0 +1,147 @@
+package wechat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestOfficeAccountUserIterator(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the last page ends with its next_openid, like wechat does
+		switch r.URL.Query().Get("next_openid") {
+		case "":
+			w.Write([]byte(`{"total":3,"count":2,"data":{"openid":["OPENID1","OPENID2"]},"next_openid":"OPENID2"}`))
+		case "OPENID2":
+			w.Write([]byte(`{"total":3,"count":1,"data":{"openid":["OPENID3"]},"next_openid":"OPENID3"}`))
+		default:
+			w.Write([]byte(`{"total":3,"count":0,"next_openid":""}`))
+		}
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(OfficeAccountHost, ts.URL))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	var openids []string
+	it := client.OfficeAccountUserGet().SetAccessToken("token").Iterator()
+	for it.Next(ctx) {
+		openids = append(openids, it.OpenIDs()...)
+	}
+	if it.Err() != nil || it.Total() != 3 || fmt.Sprint(openids) != "[OPENID1 OPENID2 OPENID3]" {
+		t.Log(it.Err(), openids)
+		t.FailNow()
+	}
+}
+
+func TestOfficeAccountUserBatchGet(t *testing.T) {
+	var sizes []int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var req struct {
+			UserList []struct {
+				OpenID string `json:"openid"`
+			} `json:"user_list"`
+		}
+		json.Unmarshal(body, &req)
+		sizes = append(sizes, len(req.UserList))
+		if req.UserList[0].OpenID == "BAD" {
+			w.Write([]byte(`{"errcode":40003,"errmsg":"invalid openid"}`))
+			return
+		}
+		users := make([]OfficeAccountUser, 0, len(req.UserList))
+		for _, u := range req.UserList {
+			users = append(users, OfficeAccountUser{Subscribe: 1, OpenID: u.OpenID})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"user_info_list": users})
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(OfficeAccountHost, ts.URL))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	openids := make([]string, 0, 250)
+	for i := 0; i < 250; i++ {
+		openids = append(openids, fmt.Sprintf("OPENID%d", i))
+	}
+	ret, err := client.OfficeAccountUserBatchGet().SetAccessToken("token").SetOpenIDs(openids...).Do(ctx)
+	if err != nil || len(ret.UserInfoList) != 250 || ret.UserInfoList[249].OpenID != "OPENID249" || fmt.Sprint(sizes) != "[100 100 50]" {
+		t.Log(err, sizes)
+		t.FailNow()
+	}
+
+	// a failed request stops the next ones, even without errcode check
+	client, err = NewClient(SetHostURL(OfficeAccountHost, ts.URL), SetCheckErrCode(false))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	sizes = nil
+	openids[100] = "BAD"
+	ret, err = client.OfficeAccountUserBatchGet().SetAccessToken("token").SetOpenIDs(openids...).Do(ctx)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrCode != ErrCodeInvalidOpenID || ret.ErrCode != ErrCodeInvalidOpenID {
+		t.Log(err)
+		t.FailNow()
+	}
+	if len(ret.UserInfoList) != 100 || len(ret.FailedOpenIDs) != 100 || ret.FailedOpenIDs[0] != "BAD" || fmt.Sprint(sizes) != "[100 100]" {
+		t.Log(len(ret.UserInfoList), ret.FailedOpenIDs, sizes)
+		t.FailNow()
+	}
+}
+
+func TestOfficeAccountBlacklistUpdate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var req struct {
+			OpenIDList []string `json:"openid_list"`
+		}
+		if r.URL.Path != "/"+OfficeAccountBlacklistAddEndpoint || json.Unmarshal(body, &req) != nil || len(req.OpenIDList) > 20 {
+			w.Write([]byte(`{"errcode":40032,"errmsg":"invalid openid list size"}`))
+			return
+		}
+		if req.OpenIDList[0] == "OPENID20" {
+			w.Write([]byte(`{"errcode":40003,"errmsg":"invalid openid"}`))
+			return
+		}
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer ts.Close()
+	client, err := NewClient(SetHostURL(OfficeAccountHost, ts.URL), SetCheckErrCode(false))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	openids := make([]string, 0, 50)
+	for i := 0; i < 50; i++ {
+		openids = append(openids, fmt.Sprintf("OPENID%d", i))
+	}
+	ret, err := client.OfficeAccountBlacklistAdd().SetAccessToken("token").SetOpenIDs(openids...).Do(ctx)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrCode != ErrCodeInvalidOpenID {
+		t.Log(err)
+		t.FailNow()
+	}
+	if len(ret.Succeeded) != 20 || len(ret.FailedOpenIDs) != 20 || ret.FailedOpenIDs[0] != "OPENID20" || ret.ErrCode != ErrCodeInvalidOpenID {
+		t.Log(ret)
+		t.FailNow()
+	}
+}
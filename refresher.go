@@ -0,0 +1,85 @@
+package wechat
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+const (
+	// refreshJitter spreads refreshes of several processes: the wait for
+	// the next refresh is changed randomly by up to this fraction.
+	refreshJitter = 0.1
+	// refreshInitialBackoff and refreshMaxBackoff limit the wait after
+	// failed refreshes.
+	refreshInitialBackoff = 1 * time.Second
+	refreshMaxBackoff     = 5 * time.Minute
+)
+
+// RegisterAccessToken keeps the token of iat warm: while the client is
+// running, a background process refreshes it after a fraction of its
+// lifetime, see SetRefreshFraction. Registering the same token twice is a
+// no-op.
+func (c *Client) RegisterAccessToken(iat IAccessToken) {
+	key := c.BasicAccessToken(iat).cacheKey()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.refreshSources[key]; ok {
+		return
+	}
+	c.refreshSources[key] = iat
+	if c.running {
+		c.startRefresher(c.bgctx, iat)
+	}
+}
+
+// startRefresher runs the refresher of iat until ctx is canceled.
+// The caller must hold c.mu.
+func (c *Client) startRefresher(ctx context.Context, iat IAccessToken) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.refresher(ctx, iat)
+	}()
+}
+
+// refresher refreshes the token of iat whenever it is due, backing off
+// exponentially on errors.
+func (c *Client) refresher(ctx context.Context, iat IAccessToken) {
+	c.mu.RLock()
+	fraction := c.refreshFraction
+	c.mu.RUnlock()
+
+	bat := c.BasicAccessToken(iat)
+	backoff := NewExponentialBackoff(refreshInitialBackoff, refreshMaxBackoff, int(^uint(0)>>1))
+	failures := 0
+	for {
+		var wait time.Duration
+		due, err := bat.refreshIfDue(ctx, fraction)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			failures++
+			wait, _ = backoff.Next(failures)
+			c.errorf("wechat: background refresh of access token failed %d times, next try in %v: %v", failures, wait, err)
+		} else {
+			failures = 0
+			wait = time.Until(due)
+			wait += time.Duration((rand.Float64()*2 - 1) * refreshJitter * float64(wait))
+			if wait < refreshInitialBackoff {
+				wait = refreshInitialBackoff
+			}
+			c.tracef("wechat: next background refresh of access token in %v", wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package wechat
 
 import (
+	"bufio"
 	"encoding/json"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
 
 	"github.com/pkg/errors"
@@ -23,6 +25,88 @@ type Response struct {
 	Header http.Header
 	// Body is the deserialized response body.
 	Body json.RawMessage
+	// Stream is the body of a streamed response, see
+	// PerformRequestOptions.Stream. It is nil if the body was read into Body.
+	Stream io.ReadCloser
+}
+
+// ContentType returns the media type of the response, e.g. image/jpeg.
+func (r *Response) ContentType() string {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return r.Header.Get("Content-Type")
+	}
+	return mediaType
+}
+
+// Filename returns the filename of the Content-Disposition header, or "" if
+// there is none.
+func (r *Response) Filename() string {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Disposition"))
+	if err != nil {
+		return ""
+	}
+	return params["filename"]
+}
+
+// Close closes the Stream of a streamed response.
+func (r *Response) Close() error {
+	if r.Stream == nil {
+		return nil
+	}
+	return r.Stream.Close()
+}
+
+// streamBody is the buffered body of a streamed response.
+type streamBody struct {
+	io.Reader
+	io.Closer
+}
+
+// isJSONBody returns true if a response body is JSON, judging by the
+// content type or, if it doesn't tell, the first byte.
+func isJSONBody(contentType string, body *bufio.Reader) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json", "text/json", "text/plain":
+		return true
+	case "", "application/octet-stream":
+		b, err := body.Peek(1)
+		return err == nil && b[0] == '{'
+	}
+	return false
+}
+
+// decodeResponse decodes the body of res into ret, the response struct of
+// api. Unless disabled with SetCheckErrCode, a non-zero errcode in the body
+// is returned as an *APIError, with ret decoded nonetheless.
+func (c *Client) decodeResponse(api string, res *Response, ret interface{}) error {
+	if err := c.decoder.Decode(res.Body, ret); err != nil {
+		return errors.Wrap(err, "Response.Decode")
+	}
+	c.mu.RLock()
+	check := c.checkErrCode
+	c.mu.RUnlock()
+	if !check {
+		return nil
+	}
+	if ce := peekCommonError(res.Body); ce != nil {
+		return NewAPIError(api, *ce)
+	}
+	return nil
+}
+
+// decodeChunkResponse is like decodeResponse, but always returns a non-zero
+// errcode as an *APIError. Builders that split a request into chunks use it
+// to tell the failed chunks even if SetCheckErrCode is disabled.
+func (c *Client) decodeChunkResponse(api string, res *Response, ret interface{}) error {
+	if err := c.decoder.Decode(res.Body, ret); err != nil {
+		return errors.Wrap(err, "Response.Decode")
+	}
+	if ce := peekCommonError(res.Body); ce != nil {
+		return NewAPIError(api, *ce)
+	}
+	return nil
 }
 
 // newResponse creates a new response from the HTTP response.
@@ -0,0 +1,115 @@
+package wechat
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultRetryErrCodes are the errcodes retried by a BackoffRetrier:
+// -1 system busy.
+var DefaultRetryErrCodes = []int64{-1}
+
+// Retrier decides whether to retry a failed HTTP request to wechat.
+// Set it per client with SetRetrier or per request with
+// PerformRequestOptions.Retrier.
+type Retrier interface {
+	// Retry is called when a request failed. retry is the number of the
+	// retry, starting at 1. req is the failed request and resp its response,
+	// which is nil on network errors. err is the network error, an *Error
+	// for 5xx responses or an *APIError if wechat answered with an errcode.
+	//
+	// Retry returns the time to wait before the next attempt, whether to
+	// retry at all, and an error that aborts the request.
+	Retry(ctx context.Context, retry int, req *http.Request, resp *http.Response, err error) (time.Duration, bool, error)
+}
+
+// -- StopRetrier --
+
+// StopRetrier is an implementation that does no retries. It is used by
+// default.
+type StopRetrier struct{}
+
+// NewStopRetrier returns a retrier that does no retries.
+func NewStopRetrier() *StopRetrier {
+	return &StopRetrier{}
+}
+
+// Retry does not retry.
+func (r *StopRetrier) Retry(ctx context.Context, retry int, req *http.Request, resp *http.Response, err error) (time.Duration, bool, error) {
+	return 0, false, nil
+}
+
+// -- BackoffRetrier --
+
+// BackoffRetrier is an implementation that retries network errors, 5xx
+// responses and a set of errcodes, waiting as long as its Backoff says.
+// It doesn't retry if the wait would exceed the deadline of the context.
+type BackoffRetrier struct {
+	backoff  Backoff
+	errcodes map[int64]bool
+}
+
+// NewBackoffRetrier returns a retrier that uses the given backoff strategy
+// and retries DefaultRetryErrCodes.
+func NewBackoffRetrier(backoff Backoff) *BackoffRetrier {
+	r := &BackoffRetrier{backoff: backoff}
+	r.SetErrCodes(DefaultRetryErrCodes...)
+	return r
+}
+
+// SetErrCodes sets the errcodes to retry, replacing DefaultRetryErrCodes.
+func (r *BackoffRetrier) SetErrCodes(errcodes ...int64) *BackoffRetrier {
+	r.errcodes = make(map[int64]bool, len(errcodes))
+	for _, code := range errcodes {
+		r.errcodes[code] = true
+	}
+	return r
+}
+
+// Retry calls into the backoff strategy and its wait interval.
+func (r *BackoffRetrier) Retry(ctx context.Context, retry int, req *http.Request, resp *http.Response, err error) (time.Duration, bool, error) {
+	if !r.retryable(resp, err) {
+		return 0, false, nil
+	}
+	wait, goahead := r.backoff.Next(retry)
+	if !goahead {
+		return 0, false, nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+		return 0, false, nil
+	}
+	return wait, true, nil
+}
+
+// retryable returns true for network errors, 5xx and retryable errcodes.
+func (r *BackoffRetrier) retryable(resp *http.Response, err error) bool {
+	if ae, ok := errors.Cause(err).(*APIError); ok {
+		return r.errcodes[ae.ErrCode]
+	}
+	if resp == nil {
+		return err != nil
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retry asks retrier whether a failed attempt should be repeated and waits
+// for the returned interval. It returns an error if the context is done
+// while waiting.
+func (c *Client) retry(ctx context.Context, retrier Retrier, retry int, req *Request, resp *http.Response, cause error) (bool, error) {
+	wait, goahead, err := retrier.Retry(ctx, retry, (*http.Request)(req), resp, cause)
+	if err != nil || !goahead {
+		return false, err
+	}
+	c.infof("wechat: retry %d of %s %s in %v: %v", retry, req.Method, req.URL, wait, cause)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-timer.C:
+		return true, nil
+	}
+}
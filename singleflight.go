@@ -0,0 +1,52 @@
+package wechat
+
+import (
+	"context"
+	"sync"
+)
+
+// flightCall is an in-flight flightGroup.Do call.
+type flightCall struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+}
+
+// flightGroup deduplicates concurrent calls with the same key: only the
+// first caller runs fn, the others wait for its result. Unlike
+// golang.org/x/sync/singleflight, waiters give up when their own context
+// is done. The zero value is ready to use.
+type flightGroup struct {
+	mu sync.Mutex
+	m  map[string]*flightCall
+}
+
+// Do runs fn once for all concurrent callers with the same key. shared
+// reports whether the result was produced by another caller.
+func (g *flightGroup) Do(ctx context.Context, key string, fn func() (interface{}, error)) (v interface{}, shared bool, err error) {
+	g.mu.Lock()
+	if g.m == nil {
+		g.m = make(map[string]*flightCall)
+	}
+	if c, ok := g.m[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-c.done:
+			return c.val, true, c.err
+		case <-ctx.Done():
+			return nil, true, ctx.Err()
+		}
+	}
+	c := &flightCall{done: make(chan struct{})}
+	g.m[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.m, key)
+		g.mu.Unlock()
+		close(c.done)
+	}()
+	c.val, c.err = fn()
+	return c.val, false, c.err
+}
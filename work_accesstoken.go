@@ -78,7 +78,7 @@ func (wat *WorkAccessToken) Do(ctx context.Context) (*WorkAccessTokenResponse, e
 	res, err := wat.client.PerformRequest(ctx, PerformRequestOptions{
 		Method:   http.MethodGet,
 		Params:   params,
-		BaseURI:  WorkBaseHost,
+		BaseURI:  WorkHost,
 		Endpoint: WorkAccessTokenEndpoint,
 	})
 	if err != nil {
@@ -86,8 +86,8 @@ func (wat *WorkAccessToken) Do(ctx context.Context) (*WorkAccessTokenResponse, e
 	}
 	// Return operation response
 	ret := new(WorkAccessTokenResponse)
-	if err := wat.client.decoder.Decode(res.Body, ret); err != nil {
-		return nil, errors.Wrap(err, "WorkAccessToken.Do")
+	if err := wat.client.decodeResponse(WorkAccessTokenEndpoint, res, ret); err != nil {
+		return ret, errors.Wrap(err, "WorkAccessToken.Do")
 	}
 	return ret, nil
 }
@@ -98,8 +98,8 @@ func (wat *WorkAccessToken) Credentials(ctx context.Context) (*AccessToken, erro
 	if err != nil {
 		return nil, errors.Wrap(err, "WorkAccessToken.Credentials")
 	}
-	if res.ErrCode != 0 {
-		return nil, fmt.Errorf("errcode: %v, errmsg: %s", res.ErrCode, res.ErrMsg)
+	if err := DecodeWithCommonError(WorkAccessTokenEndpoint, res.CommonError); err != nil {
+		return nil, errors.Wrap(err, "WorkAccessToken.Credentials")
 	}
 	at := &AccessToken{
 		AccessToken: res.AccessToken,
@@ -0,0 +1,299 @@
+package wechat
+
+import (
+	"encoding/xml"
+)
+
+// events of WeCom callbacks
+const (
+	EventEnterAgent         = "enter_agent"
+	EventChangeContact      = "change_contact"
+	EventTemplateCard       = "template_card_event"
+	EventSysApprovalChange  = "sys_approval_change"
+	EventOpenApprovalChange = "open_approval_change"
+	EventSysCheckin         = "sys_checkin"
+)
+
+// ChangeType of change_contact events
+const (
+	ChangeTypeCreateUser  = "create_user"
+	ChangeTypeUpdateUser  = "update_user"
+	ChangeTypeDeleteUser  = "delete_user"
+	ChangeTypeCreateParty = "create_party"
+	ChangeTypeUpdateParty = "update_party"
+	ChangeTypeDeleteParty = "delete_party"
+	ChangeTypeUpdateTag   = "update_tag"
+)
+
+// WorkMessage is a message or event pushed to a WeCom app, one of the
+// Work*Message and Work*Event types, or *WorkUnknownMessage.
+type WorkMessage interface {
+	MessageHeader() *CallbackMessageHeader
+}
+
+// -- messages --
+
+// WorkTextMessage 文本消息
+type WorkTextMessage struct {
+	CallbackMessageHeader
+	AgentID int64  `xml:"AgentID"`
+	MsgID   int64  `xml:"MsgId"`
+	Content string `xml:"Content"`
+}
+
+// WorkImageMessage 图片消息
+type WorkImageMessage struct {
+	CallbackMessageHeader
+	AgentID int64  `xml:"AgentID"`
+	MsgID   int64  `xml:"MsgId"`
+	PicURL  string `xml:"PicUrl"`
+	MediaID string `xml:"MediaId"`
+}
+
+// WorkVoiceMessage 语音消息
+type WorkVoiceMessage struct {
+	CallbackMessageHeader
+	AgentID int64  `xml:"AgentID"`
+	MsgID   int64  `xml:"MsgId"`
+	MediaID string `xml:"MediaId"`
+	Format  string `xml:"Format"`
+}
+
+// WorkVideoMessage 视频消息
+type WorkVideoMessage struct {
+	CallbackMessageHeader
+	AgentID      int64  `xml:"AgentID"`
+	MsgID        int64  `xml:"MsgId"`
+	MediaID      string `xml:"MediaId"`
+	ThumbMediaID string `xml:"ThumbMediaId"`
+}
+
+// WorkLocationMessage 位置消息
+type WorkLocationMessage struct {
+	CallbackMessageHeader
+	AgentID   int64   `xml:"AgentID"`
+	MsgID     int64   `xml:"MsgId"`
+	LocationX float64 `xml:"Location_X"`
+	LocationY float64 `xml:"Location_Y"`
+	Scale     int64   `xml:"Scale"`
+	Label     string  `xml:"Label"`
+	AppType   string  `xml:"AppType"`
+}
+
+// WorkLinkMessage 链接消息
+type WorkLinkMessage struct {
+	CallbackMessageHeader
+	AgentID     int64  `xml:"AgentID"`
+	MsgID       int64  `xml:"MsgId"`
+	Title       string `xml:"Title"`
+	Description string `xml:"Description"`
+	URL         string `xml:"Url"`
+	PicURL      string `xml:"PicUrl"`
+}
+
+// -- events --
+
+// WorkSubscribeEvent 成员关注和取消关注事件
+type WorkSubscribeEvent struct {
+	CallbackMessageHeader
+	AgentID int64 `xml:"AgentID"`
+}
+
+// WorkEnterAgentEvent 进入应用事件
+type WorkEnterAgentEvent struct {
+	CallbackMessageHeader
+	AgentID  int64  `xml:"AgentID"`
+	EventKey string `xml:"EventKey"`
+}
+
+// WorkLocationEvent 上报地理位置事件
+type WorkLocationEvent struct {
+	CallbackMessageHeader
+	AgentID   int64   `xml:"AgentID"`
+	Latitude  float64 `xml:"Latitude"`
+	Longitude float64 `xml:"Longitude"`
+	Precision float64 `xml:"Precision"`
+	AppType   string  `xml:"AppType"`
+}
+
+// WorkMenuEvent 自定义菜单事件，click 的 EventKey 为菜单 key，view 的
+// EventKey 为跳转的 url
+type WorkMenuEvent struct {
+	CallbackMessageHeader
+	AgentID  int64  `xml:"AgentID"`
+	EventKey string `xml:"EventKey"`
+}
+
+// WorkChangeContactEvent 通讯录变更事件，ChangeType 为 ChangeTypeCreateUser 等。
+// The fields set depend on the ChangeType: UserID and the member fields
+// for users, ID and the party fields for parties, TagID and the items for
+// tags. Department lists the ids separated by comma.
+type WorkChangeContactEvent struct {
+	CallbackMessageHeader
+	ChangeType string `xml:"ChangeType"`
+
+	// member
+	UserID         string `xml:"UserID"`
+	NewUserID      string `xml:"NewUserID"`
+	Name           string `xml:"Name"`
+	Department     string `xml:"Department"`
+	MainDepartment int64  `xml:"MainDepartment"`
+	IsLeaderInDept string `xml:"IsLeaderInDept"`
+	Position       string `xml:"Position"`
+	Mobile         string `xml:"Mobile"`
+	Gender         int64  `xml:"Gender"`
+	Email          string `xml:"Email"`
+	BizMail        string `xml:"BizMail"`
+	Status         int64  `xml:"Status"`
+	Avatar         string `xml:"Avatar"`
+	Alias          string `xml:"Alias"`
+	Telephone      string `xml:"Telephone"`
+	Address        string `xml:"Address"`
+
+	// party
+	ID       int64 `xml:"Id"`
+	ParentID int64 `xml:"ParentId"`
+	Order    int64 `xml:"Order"`
+
+	// tag
+	TagID         int64  `xml:"TagId"`
+	AddUserItems  string `xml:"AddUserItems"`
+	DelUserItems  string `xml:"DelUserItems"`
+	AddPartyItems string `xml:"AddPartyItems"`
+	DelPartyItems string `xml:"DelPartyItems"`
+}
+
+// WorkTemplateCardSelectedItem 模板卡片中用户的选择
+type WorkTemplateCardSelectedItem struct {
+	QuestionKey string   `xml:"QuestionKey"`
+	OptionIDs   []string `xml:"OptionIds>OptionId"`
+}
+
+// WorkTemplateCardEvent 模板卡片事件，用户点击按钮后推送。回复
+// WorkUpdateButtonReply 可以更新按钮
+type WorkTemplateCardEvent struct {
+	CallbackMessageHeader
+	AgentID       int64                          `xml:"AgentID"`
+	EventKey      string                         `xml:"EventKey"`
+	TaskID        string                         `xml:"TaskId"`
+	CardType      string                         `xml:"CardType"`
+	ResponseCode  string                         `xml:"ResponseCode"`
+	SelectedItems []WorkTemplateCardSelectedItem `xml:"SelectedItems>SelectedItem"`
+}
+
+// WorkApprovalApplyer 审批申请人
+type WorkApprovalApplyer struct {
+	UserID string `xml:"UserId"`
+	Party  string `xml:"Party"`
+}
+
+// WorkApprovalInfo 审批申请详情，SpStatus 1 审批中 2 已通过 3 已驳回 4 已撤销
+// 6 通过后撤销 7 已删除 10 已支付
+type WorkApprovalInfo struct {
+	SpNo             string              `xml:"SpNo"`
+	SpName           string              `xml:"SpName"`
+	SpStatus         int64               `xml:"SpStatus"`
+	TemplateID       string              `xml:"TemplateId"`
+	ApplyTime        int64               `xml:"ApplyTime"`
+	Applyer          WorkApprovalApplyer `xml:"Applyer"`
+	StatuChangeEvent int64               `xml:"StatuChangeEvent"`
+	ApproverUserIDs  []string            `xml:"SpRecord>Details>Approver>UserId"`
+	NotifyerUserIDs  []string            `xml:"Notifyer>UserId"`
+}
+
+// WorkApprovalChangeEvent 审批申请状态变化事件
+type WorkApprovalChangeEvent struct {
+	CallbackMessageHeader
+	AgentID      int64            `xml:"AgentID"`
+	ApprovalInfo WorkApprovalInfo `xml:"ApprovalInfo"`
+}
+
+// WorkOpenApprovalInfo 自建审批应用的审批信息，OpenSpStatus 1 审批中 2 已通过
+// 3 已驳回 4 已取消
+type WorkOpenApprovalInfo struct {
+	ThirdNo        string `xml:"ThirdNo"`
+	OpenSpName     string `xml:"OpenSpName"`
+	OpenTemplateID string `xml:"OpenTemplateId"`
+	OpenSpStatus   int64  `xml:"OpenSpStatus"`
+	ApplyTime      int64  `xml:"ApplyTime"`
+	ApplyUserName  string `xml:"ApplyUserName"`
+	ApplyUserID    string `xml:"ApplyUserId"`
+	ApplyUserParty string `xml:"ApplyUserParty"`
+	ApplyUserImage string `xml:"ApplyUsrImage"`
+}
+
+// WorkOpenApprovalChangeEvent 自建审批应用的审批状态变化事件
+type WorkOpenApprovalChangeEvent struct {
+	CallbackMessageHeader
+	AgentID      int64                `xml:"AgentID"`
+	ApprovalInfo WorkOpenApprovalInfo `xml:"ApprovalInfo"`
+}
+
+// WorkCheckinEvent 打卡事件，成员打卡后推送。The event doesn't carry the
+// check-in records, get them with the checkin data api for FromUserName.
+type WorkCheckinEvent struct {
+	CallbackMessageHeader
+	AgentID int64 `xml:"AgentID"`
+}
+
+// WorkUnknownMessage is a message or event without a type in this package,
+// Raw is the decrypted XML.
+type WorkUnknownMessage struct {
+	CallbackMessageHeader
+	AgentID int64  `xml:"AgentID"`
+	Raw     []byte `xml:"-"`
+}
+
+// workMessageTypes returns new messages by route, see callbackRoute.
+var workMessageTypes = map[string]func() WorkMessage{
+	MsgTypeText:     func() WorkMessage { return new(WorkTextMessage) },
+	MsgTypeImage:    func() WorkMessage { return new(WorkImageMessage) },
+	MsgTypeVoice:    func() WorkMessage { return new(WorkVoiceMessage) },
+	MsgTypeVideo:    func() WorkMessage { return new(WorkVideoMessage) },
+	MsgTypeLocation: func() WorkMessage { return new(WorkLocationMessage) },
+	MsgTypeLink:     func() WorkMessage { return new(WorkLinkMessage) },
+
+	callbackRoute(MsgTypeEvent, EventSubscribe):          func() WorkMessage { return new(WorkSubscribeEvent) },
+	callbackRoute(MsgTypeEvent, EventUnsubscribe):        func() WorkMessage { return new(WorkSubscribeEvent) },
+	callbackRoute(MsgTypeEvent, EventEnterAgent):         func() WorkMessage { return new(WorkEnterAgentEvent) },
+	callbackRoute(MsgTypeEvent, EventLocation):           func() WorkMessage { return new(WorkLocationEvent) },
+	callbackRoute(MsgTypeEvent, EventClick):              func() WorkMessage { return new(WorkMenuEvent) },
+	callbackRoute(MsgTypeEvent, EventView):               func() WorkMessage { return new(WorkMenuEvent) },
+	callbackRoute(MsgTypeEvent, EventChangeContact):      func() WorkMessage { return new(WorkChangeContactEvent) },
+	callbackRoute(MsgTypeEvent, EventTemplateCard):       func() WorkMessage { return new(WorkTemplateCardEvent) },
+	callbackRoute(MsgTypeEvent, EventSysApprovalChange):  func() WorkMessage { return new(WorkApprovalChangeEvent) },
+	callbackRoute(MsgTypeEvent, EventOpenApprovalChange): func() WorkMessage { return new(WorkOpenApprovalChangeEvent) },
+	callbackRoute(MsgTypeEvent, EventSysCheckin):         func() WorkMessage { return new(WorkCheckinEvent) },
+}
+
+// DecodeWorkMessage decodes the plaintext XML of a message or event into
+// its type.
+func DecodeWorkMessage(raw []byte) (WorkMessage, error) {
+	unknown := &WorkUnknownMessage{Raw: raw}
+	if err := xml.Unmarshal(raw, unknown); err != nil {
+		return nil, err
+	}
+	newMessage, ok := workMessageTypes[callbackRoute(unknown.MsgType, unknown.Event)]
+	if !ok {
+		return unknown, nil
+	}
+	msg := newMessage()
+	if err := xml.Unmarshal(raw, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// WorkUpdateButtonReply 更新模板卡片按钮，回复 WorkTemplateCardEvent
+type WorkUpdateButtonReply struct {
+	OfficeAccountReplyHeader
+	ReplaceName CDATA `xml:"Button>ReplaceName"`
+}
+
+// NewWorkUpdateButtonReply return instance of WorkUpdateButtonReply
+func NewWorkUpdateButtonReply(msg WorkMessage, replaceName string) *WorkUpdateButtonReply {
+	return &WorkUpdateButtonReply{
+		OfficeAccountReplyHeader: newOfficeAccountReplyHeader(msg, "update_button"),
+		ReplaceName:              CDATA(replaceName),
+	}
+}
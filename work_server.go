@@ -0,0 +1,119 @@
+package wechat
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// WorkHandlerFunc handles a message pushed to a WeCom app. The reply is
+// sent like the one of OfficeAccountHandlerFunc, the OfficeAccount*Reply
+// types work for WeCom messages, too.
+type WorkHandlerFunc func(ctx context.Context, msg WorkMessage) (reply interface{}, err error)
+
+// WorkServer 企业微信回调服务器，is an http.Handler like OfficeAccountServer
+// for WeCom: requests are always encrypted and signed by msg_signature,
+// and the URL verification answers the decrypted echostr.
+type WorkServer struct {
+	cs callbackServer
+}
+
+// NewWorkServer return instance of WorkServer
+func NewWorkServer(client *Client) *WorkServer {
+	ws := &WorkServer{
+		cs: newCallbackServer(client, "WorkServer", "corpid", func(raw []byte) (callbackMessage, error) {
+			return DecodeWorkMessage(raw)
+		}),
+	}
+	ws.cs.wecom = true
+	return ws
+}
+
+// SetCorpID sets the CorpID, which the decrypted messages must end with.
+// Replies are encrypted for it.
+func (ws *WorkServer) SetCorpID(corpid string) *WorkServer {
+	ws.cs.crypto.receiveID = corpid
+	return ws
+}
+
+// AddReceiveID accepts messages ending with other ids than the CorpID:
+// the SuiteID for callbacks of third-party apps, or "" for third-party
+// apps of individual developers.
+func (ws *WorkServer) AddReceiveID(receiveIDs ...string) *WorkServer {
+	ws.cs.crypto.receiveIDs = append(ws.cs.crypto.receiveIDs, receiveIDs...)
+	return ws
+}
+
+// SetToken 接收消息服务器配置的 Token
+func (ws *WorkServer) SetToken(token string) *WorkServer {
+	ws.cs.crypto.token = token
+	return ws
+}
+
+// SetEncodingAESKey 接收消息服务器配置的 EncodingAESKey
+func (ws *WorkServer) SetEncodingAESKey(aesKey string) *WorkServer {
+	ws.cs.crypto.aesKey = aesKey
+	return ws
+}
+
+// HandleMessage registers the handler of a message type, e.g. MsgTypeText.
+func (ws *WorkServer) HandleMessage(msgType string, handler WorkHandlerFunc) *WorkServer {
+	ws.cs.handlers[callbackRoute(msgType, "")] = ws.wrap(handler)
+	return ws
+}
+
+// HandleEvent registers the handler of an event, e.g. EventChangeContact.
+func (ws *WorkServer) HandleEvent(event string, handler WorkHandlerFunc) *WorkServer {
+	ws.cs.handlers[callbackRoute(MsgTypeEvent, event)] = ws.wrap(handler)
+	return ws
+}
+
+// HandleDefault registers the handler of messages without a handler.
+// Without it, they are answered with ReplySuccess.
+func (ws *WorkServer) HandleDefault(handler WorkHandlerFunc) *WorkServer {
+	ws.cs.fallback = ws.wrap(handler)
+	return ws
+}
+
+func (ws *WorkServer) wrap(handler WorkHandlerFunc) callbackHandler {
+	return func(ctx context.Context, msg callbackMessage) (interface{}, error) {
+		return handler(ctx, msg)
+	}
+}
+
+// SetDedupWindow sets how long messages are remembered to drop the
+// retries of wechat, DefaultCallbackDedupWindow by default, 0 disables it.
+// Retries are answered with the reply to the first delivery, a retry that
+// arrives while the handler still runs waits for its reply or is answered
+// with ReplySuccess. The client cache is used, share it and a Locker
+// between instances to deduplicate across them, see SetLocker.
+func (ws *WorkServer) SetDedupWindow(window time.Duration) *WorkServer {
+	ws.cs.setDedupWindow(window)
+	return ws
+}
+
+// SetAsync answers ReplySuccess right away and handles the messages with
+// workers, at most queueSize messages wait for them and more are answered
+// with 503, see ErrCallbackQueueFull. Passive replies of the handlers are
+// dropped, and the handlers get a context without deadline.
+func (ws *WorkServer) SetAsync(workers, queueSize int) *WorkServer {
+	ws.cs.setAsync(workers, queueSize)
+	return ws
+}
+
+// Close stops the workers of SetAsync once the queued messages are
+// handled, new messages are answered with 503 then.
+func (ws *WorkServer) Close() error {
+	ws.cs.close()
+	return nil
+}
+
+// Validate checks if the server is valid.
+func (ws *WorkServer) Validate() error {
+	return ws.cs.validate()
+}
+
+// ServeHTTP ServeHTTP
+func (ws *WorkServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws.cs.serveHTTP(w, r)
+}
@@ -0,0 +1,172 @@
+package wechat
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// workCallbackURL returns the url of a WeCom callback request, which is
+// signed by msg_signature only.
+func workCallbackURL(encrypt string, extra url.Values) string {
+	q := url.Values{}
+	for k, v := range extra {
+		q[k] = v
+	}
+	q.Set("timestamp", "1409659813")
+	q.Set("nonce", "1372623149")
+	q.Set("msg_signature", Signature(testCallbackToken, "1409659813", "1372623149", encrypt))
+	return "http://example.com/callback?" + q.Encode()
+}
+
+func TestWorkServer(t *testing.T) {
+	const corpID, suiteID = "wx5823bf96d3bd56c7", "ww4asffe99e54c0fxx"
+	client, err := NewClient()
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	var got WorkMessage
+	server := client.WorkServer().
+		SetCorpID(corpID).
+		SetToken(testCallbackToken).
+		SetEncodingAESKey(testCallbackAESKey).
+		HandleEvent(EventChangeContact, func(ctx context.Context, msg WorkMessage) (interface{}, error) {
+			got = msg
+			return nil, nil
+		}).
+		HandleEvent(EventTemplateCard, func(ctx context.Context, msg WorkMessage) (interface{}, error) {
+			got = msg
+			return NewWorkUpdateButtonReply(msg, "已处理"), nil
+		})
+
+	seal := func(receiveID, message string) string {
+		encrypted, err := EncryptMsg(RandomStr(16), []byte(message), receiveID, testCallbackAESKey)
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+		return string(encrypted)
+	}
+	changeContact := `<xml><ToUserName><![CDATA[toUser]]></ToUserName><FromUserName><![CDATA[sys]]></FromUserName><CreateTime>1403610513</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[change_contact]]></Event><ChangeType>update_user</ChangeType><UserID><![CDATA[zhangsan]]></UserID><NewUserID><![CDATA[zhangsan001]]></NewUserID><Department><![CDATA[1,2,3]]></Department></xml>`
+	templateCard := `<xml><ToUserName><![CDATA[toUser]]></ToUserName><FromUserName><![CDATA[FromUser]]></FromUserName><CreateTime>123456789</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[template_card_event]]></Event><EventKey><![CDATA[key111]]></EventKey><TaskId><![CDATA[taskid111]]></TaskId><CardType><![CDATA[vote_interaction]]></CardType><ResponseCode><![CDATA[CODE]]></ResponseCode><AgentID>1</AgentID><SelectedItems><SelectedItem><QuestionKey><![CDATA[QuestionKey1]]></QuestionKey><OptionIds><OptionId><![CDATA[OptionId1]]></OptionId><OptionId><![CDATA[OptionId2]]></OptionId></OptionIds></SelectedItem></SelectedItems></xml>`
+
+	// URL verification
+	echostr := seal(corpID, "ECHO")
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, workCallbackURL(echostr, url.Values{"echostr": {echostr}}), nil))
+	if w.Code != http.StatusOK || w.Body.String() != "ECHO" {
+		t.Log(w.Code, w.Body.String())
+		t.FailNow()
+	}
+
+	tests := []struct {
+		name      string
+		receiveID string
+		message   string
+		wantCode  int
+		check     func(msg WorkMessage) bool
+	}{
+		{
+			name:      "change contact",
+			receiveID: corpID,
+			message:   changeContact,
+			wantCode:  http.StatusOK,
+			check: func(msg WorkMessage) bool {
+				e, ok := msg.(*WorkChangeContactEvent)
+				return ok && e.ChangeType == ChangeTypeUpdateUser && e.NewUserID == "zhangsan001" && e.Department == "1,2,3"
+			},
+		},
+		{
+			name:      "template card",
+			receiveID: corpID,
+			message:   templateCard,
+			wantCode:  http.StatusOK,
+			check: func(msg WorkMessage) bool {
+				e, ok := msg.(*WorkTemplateCardEvent)
+				return ok && e.TaskID == "taskid111" && len(e.SelectedItems) == 1 && len(e.SelectedItems[0].OptionIDs) == 2
+			},
+		},
+		{name: "other receiveid", receiveID: suiteID, message: changeContact, wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			encrypt := seal(tt.receiveID, tt.message)
+			body := `<xml><ToUserName><![CDATA[` + corpID + `]]></ToUserName><AgentID><![CDATA[1]]></AgentID><Encrypt><![CDATA[` + encrypt + `]]></Encrypt></xml>`
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, workCallbackURL(encrypt, nil), strings.NewReader(body)))
+			if w.Code != tt.wantCode {
+				t.Log(w.Code, w.Body.String())
+				t.FailNow()
+			}
+			if tt.check != nil && (got == nil || !tt.check(got)) {
+				t.Log(got)
+				t.FailNow()
+			}
+		})
+	}
+
+	// the suite id is accepted once added
+	server.AddReceiveID(suiteID)
+	encrypt := seal(suiteID, strings.Replace(templateCard, "123456789", "123456790", 1))
+	body := `<xml><ToUserName><![CDATA[` + corpID + `]]></ToUserName><Encrypt><![CDATA[` + encrypt + `]]></Encrypt></xml>`
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodPost, workCallbackURL(encrypt, nil), strings.NewReader(body)))
+	sealed := new(EncryptedReply)
+	if err := xml.Unmarshal(w.Body.Bytes(), sealed); err != nil {
+		t.Log(w.Code, w.Body.String(), err)
+		t.FailNow()
+	}
+	_, raw, err := DecryptMsg(corpID, string(sealed.Encrypt), testCallbackAESKey)
+	if err != nil || !strings.Contains(string(raw), "<Button><ReplaceName><![CDATA[已处理]]></ReplaceName></Button>") {
+		t.Log(string(raw), err)
+		t.FailNow()
+	}
+}
+
+func TestDecodeWorkMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		raw   string
+		check func(msg WorkMessage) bool
+	}{
+		{
+			name: "sys approval",
+			raw:  `<xml><ToUserName><![CDATA[wwddddccc7775555aaa]]></ToUserName><FromUserName><![CDATA[sys]]></FromUserName><CreateTime>1571732272</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[sys_approval_change]]></Event><AgentID>3010040</AgentID><ApprovalInfo><SpNo>201910220035</SpNo><SpName><![CDATA[请假]]></SpName><SpStatus>1</SpStatus><TemplateId><![CDATA[3TkaH5KFbrG9heEQWLJjhgpFwmqAFB4dLEnapaB7aaa]]></TemplateId><ApplyTime>1571728713</ApplyTime><Applyer><UserId><![CDATA[xiaoming]]></UserId><Party><![CDATA[1]]></Party></Applyer><SpRecord><SpStatus>1</SpStatus><ApproverAttr>1</ApproverAttr><Details><Approver><UserId><![CDATA[chuifeng]]></UserId></Approver><Speech><![CDATA[]]></Speech><SpStatus>1</SpStatus><SpTime>0</SpTime></Details></SpRecord><StatuChangeEvent>1</StatuChangeEvent></ApprovalInfo></xml>`,
+			check: func(msg WorkMessage) bool {
+				e, ok := msg.(*WorkApprovalChangeEvent)
+				return ok && e.ApprovalInfo.SpNo == "201910220035" && e.ApprovalInfo.Applyer.UserID == "xiaoming" && len(e.ApprovalInfo.ApproverUserIDs) == 1
+			},
+		},
+		{
+			name: "open approval",
+			raw:  `<xml><ToUserName><![CDATA[toUser]]></ToUserName><FromUserName><![CDATA[FromUser]]></FromUserName><CreateTime>1527838022</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[open_approval_change]]></Event><AgentID>1</AgentID><ApprovalInfo><ThirdNo><![CDATA[201806010001]]></ThirdNo><OpenSpName><![CDATA[付款]]></OpenSpName><OpenTemplateId><![CDATA[1234567890]]></OpenTemplateId><OpenSpStatus>1</OpenSpStatus><ApplyTime>1527837645</ApplyTime><ApplyUserName><![CDATA[xiaoming]]></ApplyUserName><ApplyUserId><![CDATA[1]]></ApplyUserId></ApprovalInfo></xml>`,
+			check: func(msg WorkMessage) bool {
+				e, ok := msg.(*WorkOpenApprovalChangeEvent)
+				return ok && e.ApprovalInfo.ThirdNo == "201806010001" && e.ApprovalInfo.OpenSpStatus == 1
+			},
+		},
+		{
+			name: "checkin",
+			raw:  `<xml><ToUserName><![CDATA[toUser]]></ToUserName><FromUserName><![CDATA[zhangsan]]></FromUserName><CreateTime>1348831860</CreateTime><MsgType><![CDATA[event]]></MsgType><Event><![CDATA[sys_checkin]]></Event><AgentID>3010011</AgentID></xml>`,
+			check: func(msg WorkMessage) bool {
+				e, ok := msg.(*WorkCheckinEvent)
+				return ok && e.FromUserName == "zhangsan" && e.AgentID == 3010011
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := DecodeWorkMessage([]byte(tt.raw))
+			if err != nil || !tt.check(msg) {
+				t.Log(msg, err)
+				t.FailNow()
+			}
+		})
+	}
+}